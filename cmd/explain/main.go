@@ -1,46 +1,95 @@
 package main
 
 import (
-	"log"
+	"context"
+	"embed"
+	"flag"
+	"io/fs"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	"dagame/internal/explain"
+	"dagame/internal/logger"
+	"dagame/internal/metrics"
+	"dagame/internal/persistence"
+	"dagame/internal/server"
+	"dagame/pkg/httplog"
+	"dagame/pkg/realtime"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish on SIGINT/SIGTERM.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	store := explain.NewStore()
+	dbPath := flag.String("db", "", "SQLite database file for saving games across restarts; empty keeps them in memory only")
+	flag.Parse()
+	slog.SetDefault(logger.Logger())
+	slog.Info("sse heartbeat interval", slog.Duration("interval", realtime.Heartbeat))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	collector := metrics.New("explain")
+	store := explain.NewStore(explain.WithMetrics(collector))
+	if *dbPath != "" {
+		db, err := persistence.Open(*dbPath)
+		if err != nil {
+			slog.Error("open database", slog.String("path", *dbPath), slog.Any("err", err))
+			os.Exit(1)
+		}
+		defer db.Close()
+		store.SetRepository(db.Explain())
+	}
 	handler := explain.NewHandler(store)
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(httplog.GameIDLogger(logger.Logger()))
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(15 * time.Second))
 
+	staticFS, err := fs.Sub(embeddedStatic, "static")
+	if err != nil {
+		slog.Error("load static files", slog.Any("err", err))
+		os.Exit(1)
+	}
+	r.Mount("/static", http.StripPrefix("/static", http.FileServer(http.FS(staticFS))))
+	r.Handle("/metrics", collector)
+
 	handler.RegisterRoutes(r)
 
 	addr := ":" + strings.TrimSpace(os.Getenv("PORT"))
 	if addr == ":" {
 		addr = ":8081"
 	}
-	server := &http.Server{
-		Addr:              addr,
+	srv := &http.Server{
 		Handler:           r,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      0,
 		IdleTimeout:       120 * time.Second,
 	}
-
-	log.Printf("Explain game listening on http://localhost%s", addr)
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal(err)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		slog.Error("listen", slog.String("addr", addr), slog.Any("err", err))
+		os.Exit(1)
+	}
+	slog.Info("explain game listening", slog.String("addr", addr), slog.String("url", "http://localhost"+addr))
+	if err := server.Run(ctx, srv, ln, store, shutdownTimeout); err != nil {
+		slog.Error("serve", slog.Any("err", err))
+		os.Exit(1)
 	}
 }
+
+//go:embed static/*
+var embeddedStatic embed.FS
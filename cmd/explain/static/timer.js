@@ -0,0 +1,19 @@
+(function () {
+	// Drives <progress id="round-timer">. The element is looked up on every
+	// tick so a round fragment swapped in over SSE resets the bar.
+	function tick() {
+		const el = document.getElementById("round-timer");
+		if (!el) return;
+		const startedMs = Number(el.dataset.startedMs || "0");
+		const durationMs = Number(el.dataset.durationMs || "0");
+		if (!startedMs || !durationMs) {
+			el.value = 0;
+			return;
+		}
+		const elapsed = Date.now() - startedMs;
+		el.value = Math.min(100, Math.max(0, (elapsed / durationMs) * 100));
+	}
+
+	tick();
+	setInterval(tick, 200);
+})();
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"flag"
 	"io/fs"
-	"log"
+	"log/slog"
 	"mime"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -15,50 +20,93 @@ import (
 
 	"dagame/internal/game"
 	"dagame/internal/handlers"
+	"dagame/internal/logger"
+	"dagame/internal/metrics"
+	"dagame/internal/persistence"
+	"dagame/internal/server"
+	"dagame/internal/tournament"
+	"dagame/pkg/httplog"
+	"dagame/pkg/realtime"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish on SIGINT/SIGTERM.
+const shutdownTimeout = 10 * time.Second
+
+// gameMaxAge is how long a game may live before the cleanup loop removes it.
+// Finished games are removed at the next sweep.
+const gameMaxAge = 6 * time.Hour
+
 func main() {
+	dbPath := flag.String("db", "", "SQLite database file for saving games across restarts; empty keeps them in memory only")
+	flag.Parse()
+	slog.SetDefault(logger.Logger())
+	slog.Info("sse heartbeat interval", slog.Duration("interval", realtime.Heartbeat))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	_ = mime.AddExtensionType(".js", "application/javascript")
 	_ = mime.AddExtensionType(".css", "text/css")
 
-	store := game.NewStore()
+	collector := metrics.New("unscrambler")
+	store := game.NewStore(game.WithMetrics(collector))
+	if *dbPath != "" {
+		db, err := persistence.Open(*dbPath)
+		if err != nil {
+			slog.Error("open database", slog.String("path", *dbPath), slog.Any("err", err))
+			os.Exit(1)
+		}
+		defer db.Close()
+		store.SetRepository(db)
+	}
+	store.StartCleanupLoop(ctx, gameMaxAge)
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(httplog.GameIDLogger(logger.Logger()))
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(15 * time.Second))
 
 	staticFS, err := fs.Sub(embeddedStatic, "static")
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("load static files", slog.Any("err", err))
+		os.Exit(1)
 	}
 
 	r.Mount("/static", http.StripPrefix("/static", http.FileServer(http.FS(staticFS))))
+	r.Handle("/metrics", collector)
 
 	homeHandler := handlers.NewHomeHandler(store)
 	gameHandler := handlers.NewGameHandler(store)
+	tournaments := tournament.NewTournamentStore(store)
+	tournaments.StartCleanupLoop(ctx, gameMaxAge)
+	tournamentHandler := tournament.NewTournamentHandler(tournaments)
 
 	homeHandler.RegisterRoutes(r)
 	gameHandler.RegisterRoutes(r)
+	tournamentHandler.RegisterRoutes(r)
 
 	addr := ":" + strings.TrimSpace(os.Getenv("PORT"))
 	if addr == ":" {
 		addr = ":8080"
 	}
-	server := &http.Server{
-		Addr:              addr,
+	srv := &http.Server{
 		Handler:           r,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      0,
 		IdleTimeout:       120 * time.Second,
 	}
-
-	log.Printf("listening on http://localhost%s", addr)
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal(err)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		slog.Error("listen", slog.String("addr", addr), slog.Any("err", err))
+		os.Exit(1)
+	}
+	slog.Info("unscrambler listening", slog.String("addr", addr), slog.String("url", "http://localhost"+addr))
+	if err := server.Run(ctx, srv, ln, store, shutdownTimeout); err != nil {
+		slog.Error("serve", slog.Any("err", err))
+		os.Exit(1)
 	}
 }
 
@@ -94,7 +94,9 @@
 		const nextTimerEl = container.querySelector("[data-next-timer]");
 		const tick = () => {
 			const now = Date.now();
-			const remaining = startMs + durationMs - now;
+			// Re-read the start so an owner's time extension moves the deadline.
+			const currentStartMs = Number(container.dataset.startMs || startMs);
+			const remaining = currentStartMs + durationMs - now;
 			if (timerEl) {
 				const seconds = Math.max(0, Math.ceil(remaining / 1000));
 				timerEl.textContent = seconds + "s";
@@ -143,6 +145,10 @@
 			return;
 		}
 		if (currentKey && nextKey && !currentLocked && !nextLocked) {
+			// Same round, still in play: keep the player's letter order but pick
+			// up a moved deadline.
+			currentRound.dataset.startMs = nextRound.dataset.startMs;
+			currentRound.dataset.roundKey = nextKey;
 			return;
 		}
 		area.querySelectorAll("[data-round]").forEach(cleanupRound);
@@ -150,7 +156,7 @@
 		initAllRounds(area);
 	}
 
-	function initSSE() {
+	function initStream() {
 		const root = document.querySelector("[data-stream-url]");
 		if (!root) return;
 		const streamUrl = root.dataset.streamUrl;
@@ -159,25 +165,61 @@
 		const playersArea = document.getElementById("players-area");
 		const scoresArea = document.getElementById("scores-area");
 
-		const source = new EventSource(streamUrl);
-		source.addEventListener("round", (event) => {
-			replaceRoundArea(roundArea, event.data);
-		});
-		source.addEventListener("players", (event) => {
-			if (playersArea) {
-				playersArea.innerHTML = event.data;
+		const handlers = {
+			round: (html) => replaceRoundArea(roundArea, html),
+			players: (html) => {
+				if (playersArea) {
+					playersArea.innerHTML = html;
+				}
+			},
+			scores: (html) => {
+				if (scoresArea) {
+					scoresArea.innerHTML = html;
+				}
+			},
+		};
+
+		const openSSE = () => {
+			const source = new EventSource(streamUrl);
+			Object.keys(handlers).forEach((name) => {
+				source.addEventListener(name, (event) => handlers[name](event.data));
+			});
+		};
+
+		// Prefer WebSocket, which survives proxies that buffer SSE; fall back to
+		// SSE (with its built-in reconnects) if the socket fails or drops.
+		if (!("WebSocket" in window)) {
+			openSSE();
+			return;
+		}
+		const wsUrl = new URL(streamUrl.replace(/\/stream$/, "/ws"), window.location.href);
+		wsUrl.protocol = wsUrl.protocol === "https:" ? "wss:" : "ws:";
+		let fellBack = false;
+		const fallBack = () => {
+			if (fellBack) return;
+			fellBack = true;
+			openSSE();
+		};
+		const socket = new WebSocket(wsUrl);
+		socket.addEventListener("message", (event) => {
+			let msg;
+			try {
+				msg = JSON.parse(event.data);
+			} catch (_err) {
+				return;
 			}
-		});
-		source.addEventListener("scores", (event) => {
-			if (scoresArea) {
-				scoresArea.innerHTML = event.data;
+			const handler = handlers[msg.event];
+			if (handler) {
+				handler(msg.data);
 			}
 		});
+		socket.addEventListener("error", fallBack);
+		socket.addEventListener("close", fallBack);
 	}
 
 	document.addEventListener("DOMContentLoaded", () => {
 		initAllRounds();
-		initSSE();
+		initStream();
 	});
 
 	document.addEventListener("click", async (event) => {
@@ -5,4 +5,6 @@ go 1.23.0
 require (
 	github.com/a-h/templ v0.3.977
 	github.com/go-chi/chi/v5 v5.0.12
+	github.com/mattn/go-sqlite3 v1.14.33
+	golang.org/x/crypto v0.35.0
 )
@@ -0,0 +1,33 @@
+// Package admin guards the operator endpoints shared by both servers.
+package admin
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Require only lets requests through that carry "Authorization: Bearer $ADMIN_SECRET".
+// Admin routes answer 404 while ADMIN_SECRET is unset so they stay hidden,
+// unless ENV=development, where they are open for local debugging.
+func Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := strings.TrimSpace(os.Getenv("ADMIN_SECRET"))
+		if secret == "" {
+			if os.Getenv("ENV") != "development" {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequire(t *testing.T) {
+	ok := Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	tests := []struct {
+		name     string
+		secret   string
+		env      string
+		header   string
+		wantCode int
+	}{
+		{"no secret hides the route", "", "", "", http.StatusNotFound},
+		{"no secret in development", "", "development", "", http.StatusOK},
+		{"missing token", "s3cret", "", "", http.StatusUnauthorized},
+		{"not a bearer token", "s3cret", "", "Basic s3cret", http.StatusUnauthorized},
+		{"wrong token in development", "s3cret", "development", "Bearer nope", http.StatusUnauthorized},
+		{"correct token", "s3cret", "", "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_SECRET", tt.secret)
+			t.Setenv("ENV", tt.env)
+			req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			ok.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("status %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Error("401 without WWW-Authenticate: Bearer")
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package explain
+
+import "net/http"
+
+// adminStats reports store-wide counts under the "explain" key, matching the
+// unscrambler server's "unscrambler" section so dashboards can merge the two.
+func (h *Handler) adminStats(w http.ResponseWriter, r *http.Request) {
+	writeJSONStatus(w, http.StatusOK, map[string]any{"explain": h.store.Stats()})
+}
+
+// adminGames lists the games that have not finished, oldest first.
+func (h *Handler) adminGames(w http.ResponseWriter, r *http.Request) {
+	games := h.store.ListActiveGames()
+	if games == nil {
+		games = []GameSummary{}
+	}
+	writeJSONStatus(w, http.StatusOK, map[string]any{"games": games})
+}
@@ -0,0 +1,45 @@
+package explain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestAdminStats_RequiresSecret(t *testing.T) {
+	t.Setenv("ADMIN_SECRET", "s3cret")
+	t.Setenv("ENV", "")
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+
+	for _, path := range []string{"/admin/stats", "/admin/games"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s without token: status %d, want 401", path, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("with token: status %d, want 200", rec.Code)
+	}
+	var body struct{ Explain StoreStats }
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Explain.TotalGames != 1 || body.Explain.TotalPlayers != 1 {
+		t.Errorf("stats %+v", body.Explain)
+	}
+}
@@ -0,0 +1,66 @@
+package explain
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxSessionSeconds caps rounds × round duration when MAX_SESSION_SECONDS is unset.
+const DefaultMaxSessionSeconds = 1800
+
+// Bounds on the palette size offered to the explainer each round.
+const (
+	MinEmojisPerRound = 4
+	MaxEmojisPerRound = 20
+)
+
+// GameConfig holds the settings chosen on the create-game form.
+type GameConfig struct {
+	Rounds           int
+	DurationSec      int
+	EmojisPerRound   int
+	Lang             string // word list language; also the page's lang attribute
+	AllowRepeatEmoji bool   // false limits each palette emoji to one placement
+}
+
+// SessionSeconds is the total play time, excluding cooldowns between rounds.
+func (c GameConfig) SessionSeconds() int {
+	return c.Rounds * c.DurationSec
+}
+
+// Validate reports a user-facing error when the palette size is out of range
+// or the game would run longer than maxSessionSec.
+func (c GameConfig) Validate(maxSessionSec int) error {
+	if c.EmojisPerRound < MinEmojisPerRound || c.EmojisPerRound > MaxEmojisPerRound {
+		return fmt.Errorf("emojis per round must be between %d and %d", MinEmojisPerRound, MaxEmojisPerRound)
+	}
+	if c.SessionSeconds() > maxSessionSec {
+		return fmt.Errorf("%d rounds of %d seconds is %s; games can last at most %s — try fewer or shorter rounds",
+			c.Rounds, c.DurationSec, formatSeconds(c.SessionSeconds()), formatSeconds(maxSessionSec))
+	}
+	return nil
+}
+
+// formatSeconds renders a length in whole minutes when it is one, otherwise
+// in seconds, so a short limit never reads as "0 minutes".
+func formatSeconds(sec int) string {
+	switch {
+	case sec == 60:
+		return "1 minute"
+	case sec > 0 && sec%60 == 0:
+		return fmt.Sprintf("%d minutes", sec/60)
+	default:
+		return fmt.Sprintf("%d seconds", sec)
+	}
+}
+
+// maxSessionSeconds reads MAX_SESSION_SECONDS, falling back to DefaultMaxSessionSeconds.
+func maxSessionSeconds() int {
+	secs, err := strconv.Atoi(strings.TrimSpace(os.Getenv("MAX_SESSION_SECONDS")))
+	if err != nil || secs <= 0 {
+		return DefaultMaxSessionSeconds
+	}
+	return secs
+}
@@ -0,0 +1,55 @@
+package explain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGameConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     GameConfig
+		maxSec  int
+		wantErr string // substring of the error; empty means valid
+	}{
+		{"within limit", GameConfig{Rounds: 3, DurationSec: 90, EmojisPerRound: 8}, 1800, ""},
+		{"exactly at limit", GameConfig{Rounds: 10, DurationSec: 180, EmojisPerRound: 8}, 1800, ""},
+		{"too long", GameConfig{Rounds: 10, DurationSec: 300, EmojisPerRound: 8}, 1800, "at most 30 minutes"},
+		{"limit under a minute", GameConfig{Rounds: 2, DurationSec: 30, EmojisPerRound: 8}, 45, "at most 45 seconds"},
+		{"too few emojis", GameConfig{Rounds: 1, DurationSec: 30, EmojisPerRound: MinEmojisPerRound - 1}, 1800, "emojis per round"},
+		{"too many emojis", GameConfig{Rounds: 1, DurationSec: 30, EmojisPerRound: MaxEmojisPerRound + 1}, 1800, "emojis per round"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(tt.maxSec)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaxSessionSeconds(t *testing.T) {
+	tests := []struct {
+		env  string
+		want int
+	}{
+		{"", DefaultMaxSessionSeconds},
+		{"600", 600},
+		{" 900 ", 900},
+		{"-5", DefaultMaxSessionSeconds},
+		{"soon", DefaultMaxSessionSeconds},
+	}
+	for _, tt := range tests {
+		t.Setenv("MAX_SESSION_SECONDS", tt.env)
+		if got := maxSessionSeconds(); got != tt.want {
+			t.Errorf("MAX_SESSION_SECONDS=%q: got %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
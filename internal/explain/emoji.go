@@ -0,0 +1,58 @@
+package explain
+
+import "unicode"
+
+const (
+	zeroWidthJoiner = '\u200d'
+	keycapCombiner  = '\u20e3'
+)
+
+// isSingleGrapheme reports whether s is exactly one user-perceived character.
+// It covers the sequences that show up in emoji input: variation selectors,
+// skin-tone modifiers, keycaps, tag sequences, ZWJ sequences and flag pairs.
+func isSingleGrapheme(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return false
+	}
+	if isRegionalIndicator(runes[0]) {
+		return len(runes) == 2 && isRegionalIndicator(runes[1])
+	}
+	if isExtender(runes[0]) || runes[0] == zeroWidthJoiner {
+		return false
+	}
+	for i := 1; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isExtender(r):
+		case r == zeroWidthJoiner:
+			// A joiner must glue two visible characters together.
+			if i+1 >= len(runes) || isExtender(runes[i+1]) || runes[i+1] == zeroWidthJoiner {
+				return false
+			}
+			i++
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isExtender reports whether r attaches to the preceding character.
+func isExtender(r rune) bool {
+	switch {
+	case r >= 0xfe00 && r <= 0xfe0f: // variation selectors
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // skin-tone modifiers
+		return true
+	case r >= 0xe0020 && r <= 0xe007f: // tag sequences (subdivision flags)
+		return true
+	case r == keycapCombiner:
+		return true
+	}
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
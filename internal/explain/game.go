@@ -1,16 +1,24 @@
 package explain
 
 import (
+	"context"
 	cryptoRand "crypto/rand"
 	"encoding/base32"
 	"errors"
+	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
+	"golang.org/x/crypto/bcrypt"
+
+	"dagame/internal/game"
+	"dagame/internal/metrics"
 	"dagame/pkg/realtime"
 )
 
@@ -20,7 +28,9 @@ const (
 	StatusFinished   = "finished"
 
 	DefaultEmojisPerRound = 8
-	MinPlayers           = 2
+	DefaultHintTokens     = 3
+	DefaultSkipPenalty    = 2
+	MinPlayers            = 2
 )
 
 // Emoji set for the explainer's canvas.
@@ -53,34 +63,134 @@ var DefaultEmojiPool = []string{
 
 type Store struct {
 	r *realtime.RoomStore[*Game]
+
+	onRoundAdvance func(gameID string) // set before any round loop starts
+
+	repo   Repository // optional; see SetRepository
+	loadMu sync.Mutex // serialises repository loads so a game is registered once
+
+	metrics *metrics.Collector // optional; see WithMetrics
+}
+
+func NewStore(opts ...Option) *Store {
+	s := &Store{r: realtime.NewRoomStore[*Game]()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.metrics.SetGaugeSource(s.gauges)
+	return s
+}
+
+// Option configures a Store; see NewStore.
+type Option func(*Store)
+
+// WithMetrics reports store activity to c.
+func WithMetrics(c *metrics.Collector) Option {
+	return func(s *Store) { s.metrics = c }
+}
+
+// Metrics returns the store's collector, or nil. Collector methods are safe
+// to call on nil.
+func (s *Store) Metrics() *metrics.Collector {
+	return s.metrics
 }
 
-func NewStore() *Store {
-	return &Store{r: realtime.NewRoomStore[*Game]()}
+// gauges reads the current game, stream and loop counts for the collector.
+func (s *Store) gauges() metrics.Gauges {
+	rooms := s.r.Rooms()
+	g := metrics.Gauges{ActiveGames: len(rooms), RoundLoops: s.r.LoopCount()}
+	for _, room := range rooms {
+		g.Subscribers += room.Subscribers()
+	}
+	return g
 }
 
+// CreateGame registers a new game. It does not enforce the session-length cap;
+// callers should check GameConfig.Validate first, as the createGame handler does.
 func (s *Store) CreateGame(rounds int, duration time.Duration, lang string, emojisPerRound int) *Game {
 	g := NewGame(rounds, duration, lang, emojisPerRound)
-	s.r.Create(g.ID, g)
+	s.addGame(g)
 	return g
 }
 
+// CreateGameFromConfig registers a game built from the create-game form. The
+// settings and password are applied before the game is stored or shared, so
+// the first save already holds them. Empty password lets anyone join.
+func (s *Store) CreateGameFromConfig(cfg GameConfig, password string) (*Game, error) {
+	g := NewGame(cfg.Rounds, time.Duration(cfg.DurationSec)*time.Second, cfg.Lang, cfg.EmojisPerRound)
+	g.AllowRepeatEmoji = cfg.AllowRepeatEmoji
+	if err := g.SetPassword(password); err != nil {
+		return nil, err
+	}
+	s.addGame(g)
+	return g, nil
+}
+
+// addGame registers and saves a newly built game.
+func (s *Store) addGame(g *Game) {
+	s.r.Create(g.ID, g)
+	s.saveGame(g)
+	s.metrics.GameCreated()
+}
+
 func (s *Store) GetGame(id string) (*Game, bool) {
 	room, ok := s.r.Get(id)
 	if !ok {
+		if s.repo != nil {
+			return s.loadGame(id)
+		}
 		return nil, false
 	}
 	return room.State, ok
 }
 
-func (s *Store) Broadcaster(id string) *realtime.Broadcaster {
+// Close deletes every game, stopping round loops and ending open streams.
+// Call it once the HTTP server has shut down.
+func (s *Store) Close() error {
+	return s.r.Close()
+}
+
+// Shutdown lets open streams receive in-flight events, waiting until ctx ends
+// at most, then closes the store like Close.
+func (s *Store) Shutdown(ctx context.Context) error {
+	return errors.Join(s.r.Drain(ctx), s.r.Close())
+}
+
+func (s *Store) Broadcaster(id string) *realtime.HistoryBroadcaster {
 	return s.r.Broadcaster(id)
 }
 
+// ActiveConnectionCount returns how many streams are subscribed to a game, or
+// 0 if the game is unknown.
+func (s *Store) ActiveConnectionCount(gameID string) int {
+	room, ok := s.r.Get(gameID)
+	if !ok {
+		return 0
+	}
+	return room.Subscribers()
+}
+
+// Publish notifies subscribers and, when a repository is set, saves the game:
+// handlers publish after every state change. "timer" ticks change nothing.
 func (s *Store) Publish(id string, event string) {
 	s.r.Publish(id, event)
+	if event == "timer" {
+		return
+	}
+	if room, ok := s.r.Get(id); ok {
+		s.saveGame(room.State)
+	}
+}
+
+// OnRoundAdvance registers fn to run whenever a game's round loop moves past
+// a round. Call it before any round loop starts.
+func (s *Store) OnRoundAdvance(fn func(gameID string)) {
+	s.onRoundAdvance = fn
 }
 
+// timerInterval is how often the round loop publishes a "timer" event while a game is in progress.
+const timerInterval = time.Second
+
 func (s *Store) EnsureRoundLoop(id string, _ *Game) {
 	getState := func() *Game {
 		room, ok := s.r.Get(id)
@@ -99,19 +209,121 @@ func (s *Store) EnsureRoundLoop(id string, _ *Game) {
 		}
 		advanced := state.AdvanceIfNeeded(now)
 		if advanced {
+			s.saveGame(state)
+			if s.onRoundAdvance != nil {
+				s.onRoundAdvance(id)
+			}
 			next2, ok2 := state.NextTimer(now)
 			if !ok2 {
 				return time.Time{}, nil, true
 			}
 			return next2, []string{"round", "scores", "players", "wordhint", "canvas"}, false
 		}
+		var events []string
 		// Publish wordhint when letters are revealed (50%, 75%) even if round didn't advance
 		if state.RevealLettersIfNeeded(now) {
-			return next, []string{"wordhint"}, false
+			events = append(events, "wordhint")
+			s.saveGame(state)
 		}
-		return next, nil, false
+		// Wake at least every timerInterval during play so reconnected clients resync their countdown.
+		if state.IsActive() {
+			events = append(events, "timer")
+			if next.After(now.Add(timerInterval)) {
+				next = now.Add(timerInterval)
+			}
+		}
+		return next, events, false
+	}
+	s.r.RunLoop(id, getState, tick, realtime.DefaultLoopOptions())
+}
+
+// StoreStats aggregates the games held by a Store.
+type StoreStats struct {
+	GamesByStatus map[string]int `json:"games_by_status"`
+	TotalGames    int            `json:"total_games"`
+	TotalPlayers  int            `json:"total_players"`
+	Connections   int            `json:"connections"` // open SSE/WebSocket streams
+}
+
+// Stats counts games by status, players and open stream connections.
+func (s *Store) Stats() StoreStats {
+	stats := StoreStats{GamesByStatus: make(map[string]int)}
+	for _, room := range s.r.Rooms() {
+		stats.Connections += room.Subscribers()
+		g := room.State
+		if g == nil {
+			continue
+		}
+		g.mu.Lock()
+		stats.GamesByStatus[g.Status]++
+		stats.TotalPlayers += len(g.Players)
+		g.mu.Unlock()
+		stats.TotalGames++
 	}
-	s.r.RunLoop(id, getState, tick)
+	return stats
+}
+
+// GameSummary is the operator's view of one game.
+type GameSummary struct {
+	ID           string    `json:"id"`
+	Status       string    `json:"status"`
+	PlayerCount  int       `json:"player_count"` // spectators included
+	CreatedAt    time.Time `json:"created_at"`
+	CurrentRound int       `json:"current_round"`
+}
+
+// ListActiveGames summarises every game that has not finished, oldest first.
+func (s *Store) ListActiveGames() []GameSummary {
+	var games []GameSummary
+	for _, room := range s.r.Rooms() {
+		g := room.State
+		if g == nil {
+			continue
+		}
+		g.mu.Lock()
+		if g.Status != StatusFinished {
+			games = append(games, GameSummary{
+				ID:           g.ID,
+				Status:       g.Status,
+				PlayerCount:  len(g.Players),
+				CreatedAt:    g.CreatedAt,
+				CurrentRound: g.TimedRounds.CurrentRound,
+			})
+		}
+		g.mu.Unlock()
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].CreatedAt.Before(games[j].CreatedAt) })
+	return games
+}
+
+// RecentGames returns up to limit games still in the lobby or in progress,
+// newest first by CreatedAt.
+func (s *Store) RecentGames(limit int) []*Game {
+	type candidate struct {
+		g         *Game
+		createdAt time.Time
+	}
+	var open []candidate
+	for _, room := range s.r.Rooms() {
+		g := room.State
+		if g == nil {
+			continue
+		}
+		g.mu.Lock()
+		if g.Status != StatusFinished {
+			open = append(open, candidate{g: g, createdAt: g.CreatedAt})
+		}
+		g.mu.Unlock()
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].createdAt.After(open[j].createdAt) })
+	if limit < len(open) {
+		open = open[:max(limit, 0)]
+	}
+	games := make([]*Game, 0, len(open))
+	for _, c := range open {
+		games = append(games, c.g)
+	}
+	return games
 }
 
 func (s *Store) Wake(id string) {
@@ -120,29 +332,149 @@ func (s *Store) Wake(id string) {
 
 // Game holds state for one explain game session.
 type Game struct {
-	mu          sync.Mutex
-	ID          string
-	CreatedAt   time.Time
-	TimedRounds realtime.TimedRounds
-	RoundData   []RoundData // pre-picked words per round (so explainer sees same word)
-	Status      string
-	Lang        string
-	OwnerID     string
-	Players     map[string]*Player
+	mu               sync.Mutex
+	ID               string
+	CreatedAt        time.Time
+	TimedRounds      realtime.TimedRounds
+	RoundData        []RoundData // pre-picked words per round (so explainer sees same word)
+	Status           string
+	Lang             string
+	OwnerID          string
+	Players          map[string]*Player
+	JoinOrder        []string        // player IDs in join order; drives explainer rotation
+	ReadyPlayers     map[string]bool // lobby ready-up state by player ID
+	OnlineSpectators map[string]bool // stream IDs of watchers who have not joined
+	EventLog         []GameEvent     // replay log, see replay.go
+	GuessHistory     GuessLog        // latest guesses across all rounds, oldest first; capped at maxGuessHistory
 
 	// Current round: word, explainer, canvas, revealed indices, emojis for this round
-	Word              string   // current round word (secret from guessers)
-	ExplainerID       string   // player ID of explainer this round
-	Canvas            []CanvasItem
-	RevealedIndices   []int    // indices into Word that have been revealed to guessers
-	RoundEmojis       []string // n random emojis explainer can use this round
-	EmojisPerRound    int
-	RoundWinnerID     string   // guesser who got it this round (if any)
-	RoundSolvedAt     time.Time
+	Word             string // current round word (secret from guessers)
+	ExplainerID      string // player ID of explainer this round
+	Canvas           []CanvasItem
+	CanvasHistory    [][]CanvasItem // earlier canvases this round, newest last; see UndoCanvas
+	RevealedIndices  []int          // indices into Word that have been revealed to guessers
+	RoundEmojis      []string       // n random emojis explainer can use this round
+	EmojisPerRound   int
+	AllowRepeatEmoji bool     // false limits each palette emoji to one placement per canvas
+	CustomEmojiPool  []string // owner-chosen palette source; empty means DefaultEmojiPool
+	passwordHash     []byte   // bcrypt hash of the join password; empty when anyone may join
+	RoundWinnerID    string   // guesser who got it this round (if any)
+	RoundSolvedAt    time.Time
+	LatestReaction   ReactEvent     // most recent guesser reaction
+	RoundDelta       map[string]int // player ID → points earned this round
+	prevRoundDelta   map[string]int // RoundDelta of the previous round, shown until this one ends
+	RoundEndReason   string         // why the round ended early: "solved" or "abandoned"
+	HintTokens       int            // letter reveals the explainer may still buy this round
+	SkipPenalty      int            // points an explainer loses for skipping a word
+	SkipUsed         bool           // the explainer already skipped this round's word
+	hintsUsed        int
+}
+
+// ErrNoHintTokens is returned when the explainer has spent every hint token this round.
+var ErrNoHintTokens = errors.New("no hint tokens left")
+
+// ErrAutoStarted is returned by ReadyUp when the last ready player started the game.
+var ErrAutoStarted = errors.New("all players ready; game started")
+
+// ErrInvalidCanvasItem is returned when a canvas update uses emoji outside the
+// round palette or places more items than the palette allows.
+var ErrInvalidCanvasItem = errors.New("invalid canvas item")
+
+// maxPlacementsPerEmoji bounds canvas size at EmojisPerRound * maxPlacementsPerEmoji.
+const maxPlacementsPerEmoji = 3
+
+// Canvas item sizes are font sizes in rem. A zero Size means the default.
+const (
+	minCanvasItemSize     = 0.5
+	maxCanvasItemSize     = 4.0
+	defaultCanvasItemSize = 2.0
+)
+
+// ErrNotGuesser is returned when the explainer attempts a guesser-only action.
+var ErrNotGuesser = errors.New("explainer cannot react")
+
+// ErrNotExplainer is returned when someone other than the explainer attempts
+// an explainer-only action.
+var ErrNotExplainer = errors.New("only the explainer can do that")
+
+// ErrRoundEnded is returned for round actions during the cooldown after a round ends.
+var ErrRoundEnded = errors.New("round already ended")
+
+// ErrSpectator is returned when a spectator attempts a player-only action.
+var ErrSpectator = errors.New("spectators cannot play")
+
+// Player roles. Spectators follow the game without explaining, guessing,
+// scoring or counting towards MinPlayers.
+const (
+	RolePlayer    = "player"
+	RoleSpectator = "spectator"
+)
+
+// maxGuessHistory caps Game.GuessHistory; older attempts are dropped first.
+const maxGuessHistory = 100
+
+// recentGuesses is how many attempts Snapshot carries for the live guess feed.
+const recentGuesses = 5
+
+// GuessAttempt is one guess, right or wrong. PlayerID doubles as the player's
+// session cookie, so it is never serialised.
+type GuessAttempt struct {
+	PlayerID   string    `json:"-"`
+	PlayerName string    `json:"player_name"`
+	Round      int       `json:"round"`
+	Guess      string    `json:"guess"`
+	At         time.Time `json:"at"`
+	Correct    bool      `json:"correct"`
+}
+
+// GuessLog is a game's guess history, oldest first.
+type GuessLog []GuessAttempt
+
+// LastN returns a copy of the newest n attempts, oldest first.
+func (l GuessLog) LastN(n int) []GuessAttempt {
+	if n <= 0 {
+		return nil
+	}
+	return append([]GuessAttempt(nil), l[max(len(l)-n, 0):]...)
+}
+
+// recordGuessLocked appends an attempt to GuessHistory, dropping the oldest
+// past maxGuessHistory. Must be called with g.mu held.
+func (g *Game) recordGuessLocked(playerID, guess string, correct bool, at time.Time) {
+	name := ""
+	if p, ok := g.Players[playerID]; ok {
+		name = p.Username
+	}
+	g.GuessHistory = append(g.GuessHistory, GuessAttempt{
+		PlayerID:   playerID,
+		PlayerName: name,
+		Round:      g.TimedRounds.CurrentRound,
+		Guess:      guess,
+		At:         at,
+		Correct:    correct,
+	})
+	if over := len(g.GuessHistory) - maxGuessHistory; over > 0 {
+		g.GuessHistory = append(GuessLog(nil), g.GuessHistory[over:]...)
+	}
+}
+
+// Guesses returns a copy of the full guess history.
+func (g *Game) Guesses() []GuessAttempt {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]GuessAttempt(nil), g.GuessHistory...)
+}
+
+// ReactEvent is a single-emoji reaction sent by a guesser.
+type ReactEvent struct {
+	PlayerID string
+	Emoji    string
+	At       time.Time
 }
 
 type RoundData struct {
 	Word   string
+	Spare  string // replacement word if the explainer skips; see SkipWord
 	Emojis []string
 }
 
@@ -151,6 +483,7 @@ type CanvasItem struct {
 	Emoji string
 	X     float64
 	Y     float64
+	Size  float64 // font size in rem, minCanvasItemSize–maxCanvasItemSize
 }
 
 type Player struct {
@@ -158,6 +491,15 @@ type Player struct {
 	Username string
 	JoinedAt time.Time
 	Points   int
+	IsOnline bool // has at least one open stream
+	Role     string
+	Color    string // name colour from game.PlayerColor
+	streams  int
+}
+
+// IsSpectator reports whether the player only watches.
+func (p *Player) IsSpectator() bool {
+	return p.Role == RoleSpectator
 }
 
 func NewGame(rounds int, duration time.Duration, lang string, emojisPerRound int) *Game {
@@ -171,12 +513,16 @@ func NewGame(rounds int, duration time.Duration, lang string, emojisPerRound int
 	roundData := make([]RoundData, rounds)
 	for i := 0; i < rounds; i++ {
 		word := PickRandomWord(lang, rng)
-		emojis := pickRandomEmojis(emojisPerRound, rng)
-		roundData[i] = RoundData{Word: word, Emojis: emojis}
+		spare := PickRandomWord(lang, rng)
+		for tries := 0; spare == word && tries < 5; tries++ {
+			spare = PickRandomWord(lang, rng)
+		}
+		emojis := pickRandomEmojis(nil, emojisPerRound, rng)
+		roundData[i] = RoundData{Word: word, Spare: spare, Emojis: emojis}
 	}
 	return &Game{
-		ID:             newID(),
-		CreatedAt:      time.Now().UTC(),
+		ID:        newID(),
+		CreatedAt: time.Now().UTC(),
 		TimedRounds: realtime.TimedRounds{
 			Rounds:   rounds,
 			Duration: duration,
@@ -186,20 +532,96 @@ func NewGame(rounds int, duration time.Duration, lang string, emojisPerRound int
 		Status:           StatusLobby,
 		Lang:             lang,
 		Players:          make(map[string]*Player),
+		ReadyPlayers:     make(map[string]bool),
+		OnlineSpectators: make(map[string]bool),
 		EmojisPerRound:   emojisPerRound,
+		SkipPenalty:      DefaultSkipPenalty,
+		AllowRepeatEmoji: true,
 		Canvas:           nil,
 		RevealedIndices:  nil,
 	}
 }
 
-func pickRandomEmojis(n int, rng *rand.Rand) []string {
-	pool := make([]string, len(DefaultEmojiPool))
-	copy(pool, DefaultEmojiPool)
+// pickRandomEmojis draws n distinct emojis from custom, or from DefaultEmojiPool
+// when custom is empty.
+func pickRandomEmojis(custom []string, n int, rng *rand.Rand) []string {
+	source := DefaultEmojiPool
+	if len(custom) > 0 {
+		source = custom
+	}
+	pool := make([]string, len(source))
+	copy(pool, source)
 	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
-	if n > len(pool) {
-		n = len(pool)
+	seen := make(map[string]bool, len(pool))
+	out := make([]string, 0, n)
+	for _, e := range pool {
+		if len(out) == n {
+			break
+		}
+		if seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+// Custom emoji pool bounds, counted in distinct emojis.
+const (
+	minEmojiPoolSize = 10
+	maxEmojiPoolSize = 200
+)
+
+// ErrInvalidEmojiPool is returned by SetEmojiPool when the pool fails validation.
+var ErrInvalidEmojiPool = errors.New("invalid emoji pool")
+
+// validateEmojiPool checks a custom pool and returns it without duplicates.
+func validateEmojiPool(emojis []string) ([]string, error) {
+	seen := make(map[string]bool, len(emojis))
+	out := make([]string, 0, len(emojis))
+	for i, e := range emojis {
+		e = strings.TrimSpace(e)
+		if !utf8.ValidString(e) || !isSingleGrapheme(e) {
+			return nil, fmt.Errorf("%w: item %d (%q) is not a single emoji", ErrInvalidEmojiPool, i, e)
+		}
+		if seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	if len(out) < minEmojiPoolSize {
+		return nil, fmt.Errorf("%w: need at least %d distinct emojis, got %d", ErrInvalidEmojiPool, minEmojiPoolSize, len(out))
+	}
+	if len(out) > maxEmojiPoolSize {
+		return nil, fmt.Errorf("%w: at most %d emojis allowed, got %d", ErrInvalidEmojiPool, maxEmojiPoolSize, len(out))
+	}
+	return out, nil
+}
+
+// SetEmojiPool replaces the palette source for every round with emojis. Only
+// the owner may change it, and only in the lobby; the rounds' palettes are
+// re-drawn from the new pool.
+func (g *Game) SetEmojiPool(ownerID string, emojis []string) error {
+	pool, err := validateEmojiPool(emojis)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can change the emoji pool")
+	}
+	if g.Status != StatusLobby {
+		return errors.New("game already started")
+	}
+	g.CustomEmojiPool = pool
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := range g.RoundData {
+		g.RoundData[i].Emojis = pickRandomEmojis(pool, g.EmojisPerRound, rng)
 	}
-	return pool[:n]
+	return nil
 }
 
 func newID() string {
@@ -210,54 +632,128 @@ func newID() string {
 }
 
 func (g *Game) AddPlayer(username string) *Player {
+	return g.AddPlayerAs(username, RolePlayer)
+}
+
+// AddSpectator registers a watcher who never explains, guesses or scores.
+func (g *Game) AddSpectator(username string) *Player {
+	return g.AddPlayerAs(username, RoleSpectator)
+}
+
+// AddPlayerAs registers a participant with the given role; anything other than
+// RoleSpectator joins as a player. Spectators stay out of JoinOrder, so they
+// are never picked to explain, and never own the game.
+func (g *Game) AddPlayerAs(username, role string) *Player {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if role != RoleSpectator {
+		role = RolePlayer
+	}
 	p := &Player{
 		ID:       newID(),
 		Username: username,
 		JoinedAt: time.Now().UTC(),
+		Role:     role,
 	}
+	p.Color = game.PlayerColor(p.ID)
 	g.Players[p.ID] = p
+	if p.IsSpectator() {
+		return p
+	}
+	g.JoinOrder = append(g.JoinOrder, p.ID)
 	if g.OwnerID == "" {
 		g.OwnerID = p.ID
 	}
+	g.logEventLocked(EventJoin, p.ID, username, p.JoinedAt)
 	return p
 }
 
 func (g *Game) Start(now time.Time) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	return g.startLocked(now)
+}
+
+func (g *Game) startLocked(now time.Time) error {
 	if g.Status != StatusLobby {
 		return errors.New("game already started")
 	}
-	if len(g.Players) < MinPlayers {
+	if len(g.JoinOrder) < MinPlayers {
 		return errors.New("need at least 2 players")
 	}
 	g.Status = StatusInProgress
 	g.TimedRounds.Start(now)
 	g.startRoundLocked(now)
+	g.logEventLocked(EventStart, "", "", now)
 	return nil
 }
 
-func (g *Game) startRoundLocked(now time.Time) {
-	// Explainer for this round: rotate by round index
-	playerIDs := make([]string, 0, len(g.Players))
-	for id := range g.Players {
-		playerIDs = append(playerIDs, id)
+// ReadyUp marks a lobby player as ready. When every player is ready and at
+// least MinPlayers have joined, the game starts and ErrAutoStarted is returned.
+func (g *Game) ReadyUp(playerID string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusLobby {
+		return errors.New("game already started")
 	}
-	sort.Strings(playerIDs)
-	idx := (g.TimedRounds.CurrentRound - 1) % len(playerIDs)
-	if idx < 0 || idx >= len(playerIDs) {
-		idx = 0
+	p, ok := g.Players[playerID]
+	if !ok {
+		return errors.New("player not found")
 	}
-	g.ExplainerID = playerIDs[idx]
+	if p.IsSpectator() {
+		return ErrSpectator
+	}
+	g.ReadyPlayers[playerID] = true
+	if len(g.JoinOrder) < MinPlayers {
+		return nil
+	}
+	for _, id := range g.JoinOrder {
+		if !g.ReadyPlayers[id] {
+			return nil
+		}
+	}
+	if err := g.startLocked(now); err != nil {
+		return err
+	}
+	return ErrAutoStarted
+}
+
+func (g *Game) startRoundLocked(now time.Time) {
+	g.ExplainerID = g.explainerForRoundLocked(g.TimedRounds.CurrentRound)
 	rd := g.currentRoundDataLocked()
 	g.Word = rd.Word
 	g.RoundEmojis = rd.Emojis
 	g.Canvas = nil
+	g.CanvasHistory = nil
 	g.RevealedIndices = nil
 	g.RoundWinnerID = ""
 	g.RoundSolvedAt = time.Time{}
+	g.prevRoundDelta = g.RoundDelta
+	g.RoundDelta = make(map[string]int)
+	g.RoundEndReason = ""
+	g.HintTokens = DefaultHintTokens
+	g.hintsUsed = 0
+	g.SkipUsed = false
+}
+
+// explainerForRoundLocked returns the explainer for the given 1-based round:
+// players in join order, rotated by round index. IDs in JoinOrder that are no
+// longer in Players are skipped. Must be called with g.mu held.
+func (g *Game) explainerForRoundLocked(round int) string {
+	playerIDs := make([]string, 0, len(g.JoinOrder))
+	for _, id := range g.JoinOrder {
+		if _, ok := g.Players[id]; ok {
+			playerIDs = append(playerIDs, id)
+		}
+	}
+	if len(playerIDs) == 0 {
+		return ""
+	}
+	idx := (round - 1) % len(playerIDs)
+	if idx < 0 || idx >= len(playerIDs) {
+		idx = 0
+	}
+	return playerIDs[idx]
 }
 
 func (g *Game) currentRoundDataLocked() RoundData {
@@ -299,18 +795,22 @@ func (g *Game) advanceIfNeededLocked(now time.Time) bool {
 	if g.Status != StatusInProgress || g.TimedRounds.RoundStarted.IsZero() {
 		return false
 	}
+	round := g.TimedRounds.CurrentRound
 	advanced, finished := g.TimedRounds.Advance(now)
 	if finished {
 		g.Status = StatusFinished
+		g.logEventLocked(EventFinish, "", "", now)
 		return true
 	}
-	if advanced {
+	// A timeout also reports advanced but keeps the round; only a new round
+	// gets a fresh word, explainer and log entry.
+	if advanced && g.TimedRounds.CurrentRound > round {
 		g.RoundWinnerID = ""
 		g.RoundSolvedAt = time.Time{}
 		g.startRoundLocked(now)
-		return true
+		g.logRoundAdvanceLocked(now)
 	}
-	return false
+	return advanced
 }
 
 // AdvanceIfNeeded advances to next round or finishes game; updates TimedRounds and game state.
@@ -322,6 +822,13 @@ func (g *Game) AdvanceIfNeeded(now time.Time) bool {
 
 // RevealLettersIfNeeded reveals one letter at 50% and one at 75% of round time. Returns true if state changed.
 func (g *Game) RevealLettersIfNeeded(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.revealLettersIfNeededLocked(now)
+}
+
+// revealLettersIfNeededLocked is RevealLettersIfNeeded for callers that already hold g.mu.
+func (g *Game) revealLettersIfNeededLocked(now time.Time) bool {
 	if g.Word == "" || g.Status != StatusInProgress {
 		return false
 	}
@@ -336,16 +843,21 @@ func (g *Game) RevealLettersIfNeeded(now time.Time) bool {
 	if elapsed >= (dur*3)/4 {
 		wantRevealed = 2
 	}
-	if wantRevealed <= len(g.RevealedIndices) {
+	if wantRevealed+g.hintsUsed <= len(g.RevealedIndices) {
 		return false
 	}
-	// Pick a random unrevealed index
-	available := make([]int, 0, len(g.Word))
+	return g.revealRandomLetterLocked(now)
+}
+
+// revealRandomLetterLocked reveals one random unrevealed letter. Must be called with g.mu held.
+func (g *Game) revealRandomLetterLocked(now time.Time) bool {
+	n := utf8.RuneCountInString(g.Word)
+	available := make([]int, 0, n)
 	revealedSet := make(map[int]bool)
 	for _, i := range g.RevealedIndices {
 		revealedSet[i] = true
 	}
-	for i := 0; i < len(g.Word); i++ {
+	for i := 0; i < n; i++ {
 		if !revealedSet[i] {
 			available = append(available, i)
 		}
@@ -361,16 +873,211 @@ func (g *Game) RevealLettersIfNeeded(now time.Time) bool {
 }
 
 // UpdateCanvas replaces the canvas (explainer only). Caller holds lock or doesn't; we lock inside.
-func (g *Game) UpdateCanvas(playerID string, items []CanvasItem) bool {
+// Every item must use an emoji from this round's palette, and the canvas holds
+// at most EmojisPerRound * maxPlacementsPerEmoji items. With AllowRepeatEmoji
+// off, each emoji may appear only once.
+func (g *Game) UpdateCanvas(playerID string, items []CanvasItem) (bool, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	if g.Status != StatusInProgress || g.ExplainerID != playerID {
-		return false
+		return false, nil
 	}
+	if len(items) > g.EmojisPerRound*maxPlacementsPerEmoji {
+		return false, ErrInvalidCanvasItem
+	}
+	placed := make(map[string]bool, len(items))
+	for _, item := range items {
+		if !slices.Contains(g.RoundEmojis, item.Emoji) {
+			return false, ErrInvalidCanvasItem
+		}
+		if !g.AllowRepeatEmoji && placed[item.Emoji] {
+			return false, ErrInvalidCanvasItem
+		}
+		placed[item.Emoji] = true
+	}
+	for i := range items {
+		if items[i].Size == 0 {
+			items[i].Size = defaultCanvasItemSize
+		}
+		items[i].Size = min(max(items[i].Size, minCanvasItemSize), maxCanvasItemSize)
+	}
+	g.pushCanvasHistoryLocked()
 	g.Canvas = items
+	g.logCanvasLocked(playerID, time.Now().UTC())
+	return true, nil
+}
+
+// maxCanvasHistory bounds how many steps the explainer can undo.
+const maxCanvasHistory = 20
+
+// pushCanvasHistoryLocked saves the current canvas before it is replaced,
+// dropping the oldest entry past maxCanvasHistory.
+func (g *Game) pushCanvasHistoryLocked() {
+	if len(g.CanvasHistory) == maxCanvasHistory {
+		g.CanvasHistory = append(g.CanvasHistory[:0], g.CanvasHistory[1:]...)
+	}
+	g.CanvasHistory = append(g.CanvasHistory, g.Canvas)
+}
+
+// UndoCanvas restores the canvas to its state before the last update or clear.
+// It returns false when the caller is not the explainer or there is nothing to undo.
+func (g *Game) UndoCanvas(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress || g.ExplainerID != playerID || len(g.CanvasHistory) == 0 {
+		return false
+	}
+	last := len(g.CanvasHistory) - 1
+	g.Canvas = g.CanvasHistory[last]
+	g.CanvasHistory = g.CanvasHistory[:last]
+	g.logCanvasLocked(playerID, time.Now().UTC())
 	return true
 }
 
+// UseHintToken spends one of the explainer's hint tokens to reveal another letter
+// to the guessers, and returns the updated revealed word.
+func (g *Game) UseHintToken(playerID string, now time.Time) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress {
+		return "", errors.New("game not in progress")
+	}
+	if playerID != g.ExplainerID {
+		return "", ErrNotExplainer
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return "", ErrRoundEnded
+	}
+	if g.HintTokens <= 0 {
+		return "", ErrNoHintTokens
+	}
+	if !g.revealRandomLetterLocked(now) {
+		return "", errors.New("every letter is already revealed")
+	}
+	g.HintTokens--
+	g.hintsUsed++
+	return revealedWord(g.Word, g.RevealedIndices), nil
+}
+
+// abandonPenalty is deducted from an explainer who gives up on a word.
+const abandonPenalty = 3
+
+// ExtendRound gives the current round d more time, up to realtime.MaxExtra
+// per round in total. Only the owner may extend, and only while the round is
+// still running at now. Scoring still measures from the real round start.
+func (g *Game) ExtendRound(ownerID string, d time.Duration, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can extend the round")
+	}
+	g.advanceIfNeededLocked(now)
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return errors.New("round already ended")
+	}
+	if d <= 0 {
+		return errors.New("extension must be positive")
+	}
+	if g.TimedRounds.Extend(d) == 0 {
+		return errors.New("round cannot be extended any further")
+	}
+	return nil
+}
+
+// AbandonRound lets the explainer give up on the current word. The explainer
+// loses abandonPenalty points (never below zero) and the round ends at now.
+func (g *Game) AbandonRound(playerID string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.advanceIfNeededLocked(now)
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	if playerID != g.ExplainerID {
+		return ErrNotExplainer
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return ErrRoundEnded
+	}
+	if explainer, ok := g.Players[playerID]; ok {
+		penalty := abandonPenalty
+		if explainer.Points < penalty {
+			penalty = explainer.Points
+		}
+		explainer.Points -= penalty
+		g.RoundDelta[playerID] -= penalty
+	}
+	g.RoundEndReason = "abandoned"
+	g.TimedRounds.SkipRound(now)
+	return nil
+}
+
+// SkipWord swaps the current word for the round's spare, once per round. Only
+// the explainer may skip; the canvas and revealed letters reset and the
+// explainer loses SkipPenalty points (never below zero).
+func (g *Game) SkipWord(playerID string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.advanceIfNeededLocked(now)
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	if playerID != g.ExplainerID {
+		return errors.New("only the explainer can skip the word")
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return errors.New("round already ended")
+	}
+	if g.SkipUsed {
+		return errors.New("word already skipped this round")
+	}
+	idx := g.TimedRounds.CurrentRound - 1
+	if idx < 0 || idx >= len(g.RoundData) {
+		return errors.New("no round data")
+	}
+	spare := g.RoundData[idx].Spare
+	if spare == "" {
+		// Games saved before spares existed pick one now.
+		spare = PickRandomWord(g.Lang, rand.New(rand.NewSource(now.UnixNano())))
+	}
+	g.RoundData[idx].Word, g.RoundData[idx].Spare = spare, ""
+	g.Word = spare
+	g.Canvas = nil
+	g.CanvasHistory = nil
+	g.RevealedIndices = nil
+	g.SkipUsed = true
+	if explainer, ok := g.Players[playerID]; ok {
+		penalty := min(g.SkipPenalty, explainer.Points)
+		explainer.Points -= penalty
+		g.RoundDelta[playerID] -= penalty
+	}
+	g.logEventLocked(EventSkip, playerID, "", now)
+	return nil
+}
+
+// ClearCanvas removes every item from the canvas. Only the explainer may clear
+// it, and only while the round is running.
+func (g *Game) ClearCanvas(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	if g.ExplainerID != playerID {
+		return ErrNotExplainer
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return ErrRoundEnded
+	}
+	g.pushCanvasHistoryLocked()
+	g.Canvas = nil
+	g.logCanvasLocked(playerID, time.Now().UTC())
+	return nil
+}
+
 // SubmitGuess returns (correct, error). On correct, awards points to guesser and explainer by time remaining.
 func (g *Game) SubmitGuess(playerID string, guess string, now time.Time) (bool, error) {
 	g.mu.Lock()
@@ -381,24 +1088,30 @@ func (g *Game) SubmitGuess(playerID string, guess string, now time.Time) (bool,
 	if playerID == g.ExplainerID {
 		return false, errors.New("explainer cannot guess")
 	}
-	if _, ok := g.Players[playerID]; !ok {
+	if p, ok := g.Players[playerID]; !ok {
 		return false, errors.New("player not found")
+	} else if p.IsSpectator() {
+		return false, ErrSpectator
 	}
 	g.advanceIfNeededLocked(now)
 	if g.Status != StatusInProgress {
 		return false, nil
 	}
-	if g.RoundWinnerID != "" {
-		return false, nil // already solved
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return false, nil // solved, abandoned or timed out; waiting for the next round
 	}
 	normalized := strings.ToLower(strings.TrimSpace(guess))
 	normalized = strings.ReplaceAll(normalized, " ", "")
 	if normalized == "" || g.Word == "" {
 		return false, nil
 	}
-	if normalized != g.Word {
+	if foldAccents(normalized) != foldAccents(g.Word) {
+		g.logEventLocked(EventGuessWrong, playerID, guess, now)
+		g.recordGuessLocked(playerID, strings.TrimSpace(guess), false, now)
 		return false, nil
 	}
+	g.logEventLocked(EventGuessRight, playerID, guess, now)
+	g.recordGuessLocked(playerID, strings.TrimSpace(guess), true, now)
 	// Award points based on remaining time.
 	//
 	// Guesser:  1–10 pts  (ceil(10 * remaining/duration)) — rewards fast guessing.
@@ -421,22 +1134,179 @@ func (g *Game) SubmitGuess(playerID string, guess string, now time.Time) (bool,
 	}
 	if guesser, ok := g.Players[playerID]; ok {
 		guesser.Points += guesserPoints
+		g.RoundDelta[playerID] += guesserPoints
 	}
 	if explainer, ok := g.Players[g.ExplainerID]; ok {
 		explainer.Points += explainerPoints
+		g.RoundDelta[g.ExplainerID] += explainerPoints
 	}
 	g.RoundWinnerID = playerID
 	g.RoundSolvedAt = now
+	g.RoundEndReason = "solved"
 	g.TimedRounds.RoundEndedAt = now
 	return true, nil
 }
 
+// ReactToGuess records a one-emoji reaction from a guesser. The explainer gets ErrNotGuesser.
+func (g *Game) ReactToGuess(reactorID, emoji string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	reactor, ok := g.Players[reactorID]
+	if !ok {
+		return errors.New("player not found")
+	}
+	if reactorID == g.ExplainerID {
+		return ErrNotGuesser
+	}
+	if reactor.IsSpectator() {
+		return ErrSpectator
+	}
+	if !isSingleGrapheme(emoji) {
+		return errors.New("reaction must be a single emoji")
+	}
+	g.LatestReaction = ReactEvent{PlayerID: reactorID, Emoji: emoji, At: now}
+	return nil
+}
+
+// MarkPlayerOnline records a new stream connection for the player.
+func (g *Game) MarkPlayerOnline(playerID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p, ok := g.Players[playerID]; ok {
+		p.streams++
+		p.IsOnline = true
+	}
+}
+
+// MarkPlayerOffline records a closed stream; the player stays online while other tabs are open.
+func (g *Game) MarkPlayerOffline(playerID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p, ok := g.Players[playerID]; ok && p.streams > 0 {
+		p.streams--
+		p.IsOnline = p.streams > 0
+	}
+}
+
+// MarkSpectatorOnline records an open stream from a viewer who has not joined.
+func (g *Game) MarkSpectatorOnline(spectatorID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.OnlineSpectators[spectatorID] = true
+}
+
+// MarkSpectatorOffline forgets a spectator stream.
+func (g *Game) MarkSpectatorOffline(spectatorID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.OnlineSpectators, spectatorID)
+}
+
+// ObserverCount returns how many spectators are watching.
+func (g *Game) ObserverCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.OnlineSpectators)
+}
+
+// PlayerCount returns the number of players, not counting spectators, without
+// exposing the Players map.
+func (g *Game) PlayerCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.JoinOrder)
+}
+
+// HasMinimumPlayers reports whether enough players have joined to start.
+func (g *Game) HasMinimumPlayers() bool {
+	return g.PlayerCount() >= MinPlayers
+}
+
 func (g *Game) IsOwner(playerID string) bool {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	return playerID != "" && playerID == g.OwnerID
 }
 
+// IsProtected reports whether joining requires a password.
+func (g *Game) IsProtected() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.passwordHash) > 0
+}
+
+// MaxPasswordLength is the longest join password in bytes; bcrypt ignores
+// anything past it, so SetPassword rejects longer ones.
+const MaxPasswordLength = 72
+
+// SetPassword sets the password new players need to join; empty lets anyone
+// join. Only a bcrypt hash is kept.
+func (g *Game) SetPassword(password string) error {
+	var hash []byte
+	if password != "" {
+		var err error
+		if hash, err = bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost); err != nil {
+			return err
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.passwordHash = hash
+	return nil
+}
+
+// CheckPassword reports whether password lets a new player join. Games
+// without a password accept anything.
+func (g *Game) CheckPassword(password string) bool {
+	g.mu.Lock()
+	hash := g.passwordHash
+	g.mu.Unlock()
+	if len(hash) == 0 {
+		return true
+	}
+	// bcrypt is deliberately slow, so compare without holding the lock.
+	return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil
+}
+
+// IsLobby reports whether the game is still waiting to start.
+func (g *Game) IsLobby() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusLobby
+}
+
+// IsActive reports whether rounds are being played.
+func (g *Game) IsActive() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusInProgress
+}
+
+// RoundTimeLeft reports how long the current round has left, or zero once it
+// has ended. ok is false unless the game is in progress. It reads only the
+// round timing, so it is cheap enough to call on every timer tick.
+func (g *Game) RoundTimeLeft(now time.Time) (remaining time.Duration, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress {
+		return 0, false
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return 0, true
+	}
+	return max(g.TimedRounds.Deadline().Sub(now), 0), true
+}
+
+// IsFinished reports whether the last round has ended.
+func (g *Game) IsFinished() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusFinished
+}
+
 func (g *Game) PlayerName(playerID string) (string, bool) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -450,7 +1320,7 @@ func (g *Game) PlayerName(playerID string) (string, bool) {
 func (g *Game) WordLength() int {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	return len(g.Word)
+	return utf8.RuneCountInString(g.Word)
 }
 
 // RevealedWordForGuessers returns the word with only revealed positions filled (e.g. "a__l_").
@@ -484,55 +1354,117 @@ func revealedWord(word string, indices []int) string {
 
 // Snapshot for rendering.
 type Snapshot struct {
-	ID              string
-	Status          string
-	CurrentRound    int
-	Rounds          int
-	RoundDuration   time.Duration
-	RoundStarted    time.Time
-	RoundEndedAt    time.Time
-	NextRoundAt     time.Time
-	WordLength      int
-	RevealedWord    string   // for guessers: e.g. "a__le"
-	Word            string   // for explainer only (set in handler when role=explainer)
-	ExplainerID     string
-	ExplainerName   string
-	RoundEmojis     []string
-	Canvas          []CanvasItem
-	Players         []PlayerInfo
-	Scores          []ScoreEntry
-	RoundWinnerName string
-	WinnerName      string
-	IsExplainer     bool
-	IsGuesser       bool
+	ID                string
+	Lang              string
+	Status            string
+	CurrentRound      int
+	Rounds            int
+	RoundDuration     time.Duration // including any extension; RoundStarted+RoundDuration is the deadline
+	RoundStarted      time.Time
+	RoundEndedAt      time.Time
+	NextRoundAt       time.Time
+	WordLength        int
+	RevealedWord      string // for guessers: e.g. "a__le"
+	Word              string // for explainer only (set in handler when role=explainer)
+	ExplainerID       string
+	ExplainerName     string
+	NextExplainerName string // empty on the last round
+	RoundEmojis       []string
+	Canvas            []CanvasItem
+	AllowRepeatEmoji  bool
+	Players           []PlayerInfo // spectators excluded
+	Spectators        []PlayerInfo
+	SpectatorCount    int
+	ObserverCount     int
+	Scores            []ScoreEntry
+	RoundWinners      []RoundWinner // solvers this round, fastest first
+	RoundWinnerName   string        // RoundWinners[0].Name, kept for older callers
+	RoundEndReason    string
+	HintTokens        int
+	SkipUsed          bool
+	IsLastRound       bool
+	WinnerName        string
+	IsExplainer       bool
+	IsGuesser         bool
+	IsReady           bool // requesting player has readied up in the lobby
+	IsSpectator       bool // requesting player joined to watch
+	IsOwner           bool // requesting player owns the game
+	Reaction          ReactEvent
+	ReactionName      string
+	RoundDelta        map[string]int // player ID → points earned in the last completed round
+	LastRoundDelta    []ScoreDelta   // players who scored in the last completed round, biggest gain first
+	RecentGuesses     []GuessAttempt // newest attempts for the live guess feed, oldest first
+	IsProtected       bool           // joining needs a password; the password itself is never included
 }
 
 type PlayerInfo struct {
-	ID       string
-	Name     string
+	ID          string
+	Name        string
 	IsExplainer bool
+	Solved      bool // guessed the word this round
+	IsOnline    bool
+	Ready       bool // readied up in the lobby
+	IsSpectator bool
+	Color       string
+}
+
+// RoundWinner is a guesser who solved the current round.
+type RoundWinner struct {
+	PlayerID string
+	Name     string
+	Rank     int // 1 for the first solver
+	Points   int // points the solver earned this round
+}
+
+// ScoreDelta is the points a player earned in the last completed round.
+type ScoreDelta struct {
+	Name  string
+	Delta int
 }
 
 type ScoreEntry struct {
 	Name   string
+	Color  string
 	Points int
+	Delta  int // points earned in the last completed round
 }
 
-func (g *Game) Snapshot(now time.Time, playerID string) Snapshot {
+// Scores returns the scoreboard, highest first, without building a full Snapshot.
+func (g *Game) Scores() []ScoreEntry {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.TimedRounds.Advance(now)
-	g.RevealLettersIfNeeded(now)
+	return g.scoresLocked()
+}
 
-	players := make([]PlayerInfo, 0, len(g.Players))
+// roundWinnersLocked lists this round's solvers. Only one guesser can solve a
+// round today, so the slice holds at most one entry. Must be called with g.mu held.
+func (g *Game) roundWinnersLocked() []RoundWinner {
+	p, ok := g.Players[g.RoundWinnerID]
+	if !ok {
+		return nil
+	}
+	return []RoundWinner{{PlayerID: p.ID, Name: p.Username, Rank: 1, Points: g.RoundDelta[p.ID]}}
+}
+
+// completedDeltaLocked returns the points earned in the last round to end:
+// the current round once it has ended, otherwise the one before it. Must be
+// called with g.mu held.
+func (g *Game) completedDeltaLocked() map[string]int {
+	if g.Status == StatusFinished || !g.TimedRounds.RoundEndedAt.IsZero() {
+		return g.RoundDelta
+	}
+	return g.prevRoundDelta
+}
+
+// scoresLocked builds the sorted scoreboard. Must be called with g.mu held.
+func (g *Game) scoresLocked() []ScoreEntry {
+	delta := g.completedDeltaLocked()
 	scores := make([]ScoreEntry, 0, len(g.Players))
 	for _, p := range g.Players {
-		players = append(players, PlayerInfo{
-			ID:          p.ID,
-			Name:        p.Username,
-			IsExplainer: p.ID == g.ExplainerID,
-		})
-		scores = append(scores, ScoreEntry{Name: p.Username, Points: p.Points})
+		if p.IsSpectator() {
+			continue
+		}
+		scores = append(scores, ScoreEntry{Name: p.Username, Color: p.Color, Points: p.Points, Delta: delta[p.ID]})
 	}
 	sort.Slice(scores, func(i, j int) bool {
 		if scores[i].Points != scores[j].Points {
@@ -540,6 +1472,38 @@ func (g *Game) Snapshot(now time.Time, playerID string) Snapshot {
 		}
 		return scores[i].Name < scores[j].Name
 	})
+	return scores
+}
+
+func (g *Game) Snapshot(now time.Time, playerID string) Snapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.advanceIfNeededLocked(now)
+	g.revealLettersIfNeededLocked(now)
+
+	roundWinners := g.roundWinnersLocked()
+	solved := make(map[string]bool, len(roundWinners))
+	for _, w := range roundWinners {
+		solved[w.PlayerID] = true
+	}
+	players := make([]PlayerInfo, 0, len(g.Players))
+	var spectators []PlayerInfo
+	for _, p := range g.Players {
+		if p.IsSpectator() {
+			spectators = append(spectators, PlayerInfo{ID: p.ID, Name: p.Username, IsOnline: p.IsOnline, IsSpectator: true, Color: p.Color})
+			continue
+		}
+		players = append(players, PlayerInfo{
+			ID:          p.ID,
+			Name:        p.Username,
+			IsExplainer: p.ID == g.ExplainerID,
+			Solved:      solved[p.ID],
+			IsOnline:    p.IsOnline,
+			Ready:       g.ReadyPlayers[p.ID],
+			Color:       p.Color,
+		})
+	}
+	scores := g.scoresLocked()
 
 	// Look up names directly — g.mu is already held, cannot call g.PlayerName() (would deadlock).
 	explainerName := ""
@@ -547,9 +1511,38 @@ func (g *Game) Snapshot(now time.Time, playerID string) Snapshot {
 		explainerName = p.Username
 	}
 	roundWinnerName := ""
-	if p, ok := g.Players[g.RoundWinnerID]; ok {
-		roundWinnerName = p.Username
+	if len(roundWinners) > 0 {
+		roundWinnerName = roundWinners[0].Name
 	}
+	isSpectator := false
+	if p, ok := g.Players[playerID]; ok {
+		isSpectator = p.IsSpectator()
+	}
+	reactionName := ""
+	if p, ok := g.Players[g.LatestReaction.PlayerID]; ok {
+		reactionName = p.Username
+	}
+	nextExplainerName := ""
+	if !g.TimedRounds.IsLastRound() {
+		if p, ok := g.Players[g.explainerForRoundLocked(g.TimedRounds.CurrentRound+1)]; ok {
+			nextExplainerName = p.Username
+		}
+	}
+	completed := g.completedDeltaLocked()
+	roundDelta := make(map[string]int, len(completed))
+	lastRoundDelta := make([]ScoreDelta, 0, len(completed))
+	for id, pts := range completed {
+		roundDelta[id] = pts
+		if p, ok := g.Players[id]; ok && pts != 0 {
+			lastRoundDelta = append(lastRoundDelta, ScoreDelta{Name: p.Username, Delta: pts})
+		}
+	}
+	sort.Slice(lastRoundDelta, func(i, j int) bool {
+		if lastRoundDelta[i].Delta != lastRoundDelta[j].Delta {
+			return lastRoundDelta[i].Delta > lastRoundDelta[j].Delta
+		}
+		return lastRoundDelta[i].Name < lastRoundDelta[j].Name
+	})
 	winnerName := ""
 	if g.Status == StatusFinished && len(scores) > 0 {
 		winnerName = scores[0].Name
@@ -570,26 +1563,46 @@ func (g *Game) Snapshot(now time.Time, playerID string) Snapshot {
 	}
 
 	return Snapshot{
-		ID:              g.ID,
-		Status:          g.Status,
-		CurrentRound:   g.TimedRounds.CurrentRound,
-		Rounds:         g.TimedRounds.Rounds,
-		RoundDuration:  g.TimedRounds.Duration,
-		RoundStarted:   g.TimedRounds.RoundStarted,
-		RoundEndedAt:   g.TimedRounds.RoundEndedAt,
-		NextRoundAt:    nextRoundAt,
-		WordLength:     len(g.Word),
-		RevealedWord:   revealedWord,
-		Word:           wordForView,
-		ExplainerID:    g.ExplainerID,
-		ExplainerName:  explainerName,
-		RoundEmojis:    append([]string(nil), g.RoundEmojis...),
-		Canvas:         append([]CanvasItem(nil), g.Canvas...),
-		Players:        players,
-		Scores:         scores,
-		RoundWinnerName: roundWinnerName,
-		WinnerName:     winnerName,
-		IsExplainer:    playerID == g.ExplainerID,
-		IsGuesser:      playerID != "" && playerID != g.ExplainerID,
+		ID:                g.ID,
+		Lang:              g.Lang,
+		Status:            g.Status,
+		CurrentRound:      g.TimedRounds.CurrentRound,
+		Rounds:            g.TimedRounds.Rounds,
+		RoundDuration:     g.TimedRounds.Duration + g.TimedRounds.Extra,
+		RoundStarted:      g.TimedRounds.RoundStarted,
+		RoundEndedAt:      g.TimedRounds.RoundEndedAt,
+		NextRoundAt:       nextRoundAt,
+		WordLength:        utf8.RuneCountInString(g.Word),
+		RevealedWord:      revealedWord,
+		Word:              wordForView,
+		ExplainerID:       g.ExplainerID,
+		ExplainerName:     explainerName,
+		NextExplainerName: nextExplainerName,
+		RoundEmojis:       append([]string(nil), g.RoundEmojis...),
+		Canvas:            append([]CanvasItem(nil), g.Canvas...),
+		AllowRepeatEmoji:  g.AllowRepeatEmoji,
+		Players:           players,
+		Spectators:        spectators,
+		SpectatorCount:    len(spectators),
+		ObserverCount:     len(g.OnlineSpectators),
+		Scores:            scores,
+		RoundWinners:      roundWinners,
+		RoundWinnerName:   roundWinnerName,
+		RoundEndReason:    g.RoundEndReason,
+		HintTokens:        g.HintTokens,
+		SkipUsed:          g.SkipUsed,
+		IsLastRound:       g.TimedRounds.IsLastRound(),
+		WinnerName:        winnerName,
+		IsExplainer:       playerID == g.ExplainerID,
+		IsGuesser:         playerID != "" && playerID != g.ExplainerID && !isSpectator,
+		IsReady:           g.ReadyPlayers[playerID],
+		IsSpectator:       isSpectator,
+		IsOwner:           playerID != "" && playerID == g.OwnerID,
+		Reaction:          g.LatestReaction,
+		ReactionName:      reactionName,
+		RoundDelta:        roundDelta,
+		LastRoundDelta:    lastRoundDelta,
+		RecentGuesses:     g.GuessHistory.LastN(recentGuesses),
+		IsProtected:       len(g.passwordHash) > 0,
 	}
 }
@@ -0,0 +1,1191 @@
+package explain
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"dagame/internal/metrics"
+	"dagame/pkg/realtime"
+)
+
+func TestGame_ExplainerFollowsJoinOrder(t *testing.T) {
+	g := NewGame(3, time.Minute, "en", DefaultEmojisPerRound)
+	first := g.AddPlayer("zoe")
+	second := g.AddPlayer("adam")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if g.ExplainerID != first.ID {
+		t.Errorf("round 1 explainer %q, want first joiner %q", g.ExplainerID, first.ID)
+	}
+
+	g.TimedRounds.SkipRound(now)
+	g.AdvanceIfNeeded(now.Add(g.TimedRounds.Cooldown + time.Second))
+	if g.ExplainerID != second.ID {
+		t.Errorf("round 2 explainer %q, want second joiner %q", g.ExplainerID, second.ID)
+	}
+}
+
+func TestGame_Snapshot_NextExplainerName(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := g.Snapshot(now, "").NextExplainerName; got != "bob" {
+		t.Errorf("round 1 NextExplainerName = %q, want bob", got)
+	}
+
+	g.TimedRounds.SkipRound(now)
+	next := now.Add(g.TimedRounds.Cooldown + time.Second)
+	if !g.AdvanceIfNeeded(next) {
+		t.Fatal("round 2 did not start after the cooldown")
+	}
+	if got := g.Snapshot(next, "").NextExplainerName; got != "" {
+		t.Errorf("last round NextExplainerName = %q, want empty", got)
+	}
+}
+
+func TestGame_Snapshot_StartsNextRoundAfterCooldown(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	g.TimedRounds.SkipRound(now)
+
+	snap := g.Snapshot(now.Add(g.TimedRounds.Cooldown+time.Second), "")
+	if snap.CurrentRound != 2 {
+		t.Fatalf("CurrentRound = %d, want 2", snap.CurrentRound)
+	}
+	if snap.ExplainerID != bob.ID {
+		t.Errorf("round 2 explainer = %q, want bob", snap.ExplainerID)
+	}
+	if want := g.RoundData[1].Word; g.Word != want {
+		t.Errorf("round 2 word = %q, want %q", g.Word, want)
+	}
+}
+
+func TestGame_AdvanceIfNeeded_TimeoutKeepsRound(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainer := g.ExplainerID
+	g.Players[explainer].Points = 5
+	if err := g.SkipWord(explainer, now); err != nil {
+		t.Fatalf("SkipWord: %v", err)
+	}
+	word := g.Word
+
+	timeout := g.TimedRounds.Deadline().Add(time.Second)
+	if !g.AdvanceIfNeeded(timeout) {
+		t.Fatal("timeout not reported")
+	}
+	if g.ExplainerID != explainer || g.Word != word {
+		t.Errorf("timeout started a new round: explainer %q word %q", g.ExplainerID, g.Word)
+	}
+	if got := g.RoundDelta[explainer]; got != -g.SkipPenalty {
+		t.Errorf("RoundDelta after timeout = %d, want the skip penalty %d", got, -g.SkipPenalty)
+	}
+
+	g.AdvanceIfNeeded(timeout.Add(g.TimedRounds.Cooldown + time.Second))
+	advances := 0
+	for _, e := range g.EventLog {
+		if e.Type == EventRoundAdvance {
+			advances++
+			if e.Data != "2" {
+				t.Errorf("round_advance logged for round %s, want 2", e.Data)
+			}
+		}
+	}
+	if advances != 1 {
+		t.Errorf("logged %d round advances, want 1", advances)
+	}
+}
+
+func TestGame_ExplainerSkipsDepartedPlayers(t *testing.T) {
+	g := NewGame(3, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	carol := g.AddPlayer("carol")
+	delete(g.Players, bob.ID) // e.g. restored state that lost a player
+
+	for round, want := range map[int]string{1: alice.ID, 2: carol.ID, 3: alice.ID} {
+		if got := g.explainerForRoundLocked(round); got != want {
+			t.Errorf("round %d explainer = %s, want %s", round, got, want)
+		}
+	}
+}
+
+func TestGame_Snapshot_ConcurrentNoDeadlock(t *testing.T) {
+	g := NewGame(2, 100*time.Millisecond, "en", DefaultEmojisPerRound)
+	a := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	start := time.Now().UTC()
+	if err := g.Start(start); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				now := start.Add(time.Duration(i*10) * time.Millisecond)
+				for j := 0; j < 50; j++ {
+					g.Snapshot(now, a.ID)
+					g.RevealLettersIfNeeded(now)
+				}
+			}(i)
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrent Snapshot calls deadlocked")
+	}
+}
+
+func TestGame_ReadyUp_NeedsMinPlayers(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	now := time.Now().UTC()
+	if err := g.ReadyUp(alice.ID, now); err != nil {
+		t.Fatalf("ReadyUp(alice): %v", err)
+	}
+	if g.Status != StatusLobby {
+		t.Fatalf("a lone ready player started the game")
+	}
+	bob := g.AddPlayer("bob")
+	if err := g.ReadyUp(bob.ID, now); err != ErrAutoStarted {
+		t.Fatalf("ReadyUp(bob) = %v, want ErrAutoStarted", err)
+	}
+	if g.Status != StatusInProgress {
+		t.Errorf("Status %q, want %q", g.Status, StatusInProgress)
+	}
+}
+
+func TestGame_UpdateCanvas_EnforcesPalette(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 2)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	offPalette := "🦄"
+	for _, e := range DefaultEmojiPool {
+		if e != g.RoundEmojis[0] && e != g.RoundEmojis[1] {
+			offPalette = e
+			break
+		}
+	}
+	if _, err := g.UpdateCanvas(g.ExplainerID, []CanvasItem{{ID: "1", Emoji: offPalette}}); err != ErrInvalidCanvasItem {
+		t.Errorf("off-palette emoji: err %v, want ErrInvalidCanvasItem", err)
+	}
+	items := make([]CanvasItem, 7)
+	for i := range items {
+		items[i] = CanvasItem{ID: string(rune('a' + i)), Emoji: g.RoundEmojis[0]}
+	}
+	if _, err := g.UpdateCanvas(g.ExplainerID, items); err != ErrInvalidCanvasItem {
+		t.Errorf("7 items with 2 palette emojis: err %v, want ErrInvalidCanvasItem", err)
+	}
+	if ok, err := g.UpdateCanvas(g.ExplainerID, items[:6]); !ok || err != nil {
+		t.Errorf("6 items: got %v, %v", ok, err)
+	}
+}
+
+func TestGame_UpdateCanvas_NoRepeatEmoji(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 4)
+	g.AllowRepeatEmoji = false
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	twice := []CanvasItem{{ID: "1", Emoji: g.RoundEmojis[0]}, {ID: "2", Emoji: g.RoundEmojis[0]}}
+	if _, err := g.UpdateCanvas(g.ExplainerID, twice); err != ErrInvalidCanvasItem {
+		t.Errorf("repeated emoji: err %v, want ErrInvalidCanvasItem", err)
+	}
+	distinct := []CanvasItem{{ID: "1", Emoji: g.RoundEmojis[0]}, {ID: "2", Emoji: g.RoundEmojis[1]}}
+	if ok, err := g.UpdateCanvas(g.ExplainerID, distinct); !ok || err != nil {
+		t.Errorf("distinct emojis: got %v, %v", ok, err)
+	}
+}
+
+func TestPickRandomEmojis_Unique(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for run := 0; run < 1000; run++ {
+		seen := make(map[string]bool)
+		for _, e := range pickRandomEmojis(nil, DefaultEmojisPerRound, rng) {
+			if seen[e] {
+				t.Fatalf("run %d: duplicate emoji %q", run, e)
+			}
+			seen[e] = true
+		}
+	}
+}
+
+func TestGame_EventLog(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob.ID
+	if g.ExplainerID == bob.ID {
+		guesser = alice.ID
+	}
+	_, _ = g.SubmitGuess(guesser, "definitely-wrong", now)
+	_, _ = g.SubmitGuess(guesser, g.Word, now)
+
+	if _, err := g.Replay(bob.ID); err != ErrReplayHidden {
+		t.Errorf("Replay by non-owner mid-game: err %v, want ErrReplayHidden", err)
+	}
+	events, err := g.Replay(alice.ID)
+	if err != nil {
+		t.Fatalf("Replay by owner: %v", err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	want := []string{EventJoin, EventJoin, EventStart, EventGuessWrong, EventGuessRight}
+	if len(types) != len(want) {
+		t.Fatalf("events %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("events %v, want %v", types, want)
+		}
+	}
+}
+
+func TestGame_EventLog_Bounded(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainer, guesser := alice.ID, bob.ID
+	if g.ExplainerID == bob.ID {
+		explainer, guesser = bob.ID, alice.ID
+	}
+
+	// Canvas edits between two guesses collapse into the latest canvas.
+	for i := 0; i < 5; i++ {
+		item := CanvasItem{ID: strconv.Itoa(i), Emoji: g.RoundEmojis[0], X: float64(i), Y: 0}
+		if ok, err := g.UpdateCanvas(explainer, []CanvasItem{item}); !ok || err != nil {
+			t.Fatalf("UpdateCanvas = %v, %v", ok, err)
+		}
+	}
+	events, _ := g.Replay(alice.ID)
+	if last := events[len(events)-1]; len(events) != 4 || last.Type != EventCanvas || !strings.Contains(last.Data, `"ID":"4"`) {
+		t.Fatalf("events %+v, want join, join, start and one canvas with the last edit", events)
+	}
+
+	for i := 0; i < 2*maxEventLog; i++ {
+		_, _ = g.SubmitGuess(guesser, "wrong-"+strconv.Itoa(i), now)
+	}
+	events, _ = g.Replay(alice.ID)
+	if len(events) != maxEventLog {
+		t.Fatalf("EventLog has %d entries, want the cap %d", len(events), maxEventLog)
+	}
+	if last := events[len(events)-1]; last.Data != "wrong-"+strconv.Itoa(2*maxEventLog-1) {
+		t.Errorf("newest event %+v, want the last guess", last)
+	}
+}
+
+func TestGame_Scores_SortedLikeSnapshot(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("bob").Points = 2
+	g.AddPlayer("alice").Points = 5
+	g.AddPlayer("carol").Points = 2
+
+	got := g.Scores()
+	want := g.Snapshot(time.Now().UTC(), "").Scores
+	if len(got) != 3 || got[0].Name != "alice" || got[1].Name != "bob" || got[2].Name != "carol" {
+		t.Fatalf("Scores() = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Scores()[%d] = %+v, Snapshot has %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGame_Snapshot_RoundWinners(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+	if snap := g.Snapshot(now, ""); len(snap.RoundWinners) != 0 || snap.RoundWinnerName != "" {
+		t.Fatalf("unsolved round has winners %+v", snap.RoundWinners)
+	}
+	if ok, _ := g.SubmitGuess(guesser.ID, g.Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+
+	snap := g.Snapshot(now, "")
+	if len(snap.RoundWinners) != 1 {
+		t.Fatalf("RoundWinners = %+v, want one entry", snap.RoundWinners)
+	}
+	w := snap.RoundWinners[0]
+	if w.PlayerID != guesser.ID || w.Rank != 1 || w.Points != guesser.Points {
+		t.Errorf("RoundWinners[0] = %+v, want %s rank 1 with %d pts", w, guesser.Username, guesser.Points)
+	}
+	if snap.RoundWinnerName != w.Name {
+		t.Errorf("RoundWinnerName = %q, want %q", snap.RoundWinnerName, w.Name)
+	}
+	for _, p := range snap.Players {
+		if p.Solved != (p.ID == guesser.ID) {
+			t.Errorf("player %s Solved = %t", p.Name, p.Solved)
+		}
+	}
+}
+
+func TestGame_Snapshot_SolvedResetsEachRound(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	g.AddPlayer("carol")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if ok, _ := g.SubmitGuess(bob.ID, g.Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	for _, p := range g.Snapshot(now, "").Players {
+		if p.Solved != (p.ID == bob.ID) {
+			t.Errorf("round 1: %s Solved = %t, want only bob", p.Name, p.Solved)
+		}
+	}
+
+	g.TimedRounds.SkipRound(now)
+	next := now.Add(g.TimedRounds.Cooldown + time.Second)
+	if !g.AdvanceIfNeeded(next) {
+		t.Fatal("round 2 did not start after the cooldown")
+	}
+	for _, p := range g.Snapshot(next, "").Players {
+		if p.Solved {
+			t.Errorf("round 2: %s still marked solved", p.Name)
+		}
+	}
+}
+
+func TestGame_Snapshot_RoundDeltaIsLastCompletedRound(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+	if snap := g.Snapshot(now, ""); len(snap.RoundDelta) != 0 {
+		t.Fatalf("RoundDelta before any round ended = %v", snap.RoundDelta)
+	}
+	if ok, _ := g.SubmitGuess(guesser.ID, g.Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	earned := g.Snapshot(now, "").RoundDelta[guesser.ID]
+	if earned <= 0 {
+		t.Fatalf("RoundDelta after solving = %d, want the guesser's points", earned)
+	}
+
+	next := now.Add(g.TimedRounds.Cooldown + time.Millisecond)
+	if !g.AdvanceIfNeeded(next) {
+		t.Fatal("round 2 did not start after the cooldown")
+	}
+	snap := g.Snapshot(next, "")
+	if snap.RoundDelta[guesser.ID] != earned {
+		t.Errorf("RoundDelta during round 2 = %v, want round 1's %d", snap.RoundDelta, earned)
+	}
+	for _, entry := range snap.Scores {
+		if entry.Name == guesser.Username && entry.Delta != earned {
+			t.Errorf("score delta during round 2 = %d, want %d", entry.Delta, earned)
+		}
+	}
+}
+
+func TestGame_ReactToGuess(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	watcher := g.AddSpectator("sam")
+	now := time.Now().UTC()
+	if err := g.ReactToGuess(bob.ID, "😂", now); err == nil {
+		t.Error("reaction accepted before the game started")
+	}
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainer, guesser := alice, bob
+	if g.ExplainerID == bob.ID {
+		explainer, guesser = bob, alice
+	}
+
+	if err := g.ReactToGuess(explainer.ID, "😂", now); !errors.Is(err, ErrNotGuesser) {
+		t.Errorf("explainer reaction err = %v, want ErrNotGuesser", err)
+	}
+	if err := g.ReactToGuess(watcher.ID, "😂", now); !errors.Is(err, ErrSpectator) {
+		t.Errorf("spectator reaction err = %v, want ErrSpectator", err)
+	}
+	for _, emoji := range []string{"", "😂😂", "ha"} {
+		if err := g.ReactToGuess(guesser.ID, emoji, now); err == nil {
+			t.Errorf("reaction %q accepted, want a single emoji", emoji)
+		}
+	}
+	if g.LatestReaction != (ReactEvent{}) {
+		t.Fatalf("rejected reactions were stored: %+v", g.LatestReaction)
+	}
+
+	at := now.Add(5 * time.Second)
+	if err := g.ReactToGuess(guesser.ID, "👍🏽", at); err != nil {
+		t.Fatalf("ReactToGuess: %v", err)
+	}
+	want := ReactEvent{PlayerID: guesser.ID, Emoji: "👍🏽", At: at}
+	if g.LatestReaction != want {
+		t.Errorf("LatestReaction = %+v, want %+v", g.LatestReaction, want)
+	}
+}
+
+func TestGame_MarkPlayerOnline_CountsStreams(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	online := func() bool {
+		for _, p := range g.Snapshot(time.Now().UTC(), "").Players {
+			if p.ID == alice.ID {
+				return p.IsOnline
+			}
+		}
+		t.Fatal("alice missing from snapshot")
+		return false
+	}
+
+	if online() {
+		t.Error("online before any stream opened")
+	}
+	g.MarkPlayerOnline(alice.ID)
+	g.MarkPlayerOnline(alice.ID) // second tab
+	g.MarkPlayerOffline(alice.ID)
+	if !online() {
+		t.Error("offline while a second tab is still streaming")
+	}
+	g.MarkPlayerOffline(alice.ID)
+	if online() {
+		t.Error("online after every stream closed")
+	}
+	g.MarkPlayerOffline(alice.ID) // extra close must not go negative
+	g.MarkPlayerOnline(alice.ID)
+	if !online() {
+		t.Error("offline after reconnecting")
+	}
+	g.MarkPlayerOnline("nobody") // unknown IDs are ignored
+}
+
+func TestGame_ObserverCount(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	g.MarkSpectatorOnline("viewer-1")
+	g.MarkSpectatorOnline("viewer-2")
+	g.MarkSpectatorOnline("viewer-2") // reconnect with the same stream ID
+	if got := g.ObserverCount(); got != 2 {
+		t.Errorf("ObserverCount = %d, want 2", got)
+	}
+	g.MarkSpectatorOffline("viewer-1")
+	snap := g.Snapshot(time.Now().UTC(), "")
+	if snap.ObserverCount != 1 {
+		t.Errorf("Snapshot.ObserverCount = %d, want 1", snap.ObserverCount)
+	}
+	if len(snap.Players) != 1 {
+		t.Errorf("Players = %+v, want observers left out", snap.Players)
+	}
+}
+
+// savedStates records what each Save call would have written.
+type savedStates struct{ saved []*Game }
+
+func (r *savedStates) Save(g *Game) error {
+	data, err := g.EncodeState()
+	if err != nil {
+		return err
+	}
+	restored, err := DecodeState(data)
+	if err != nil {
+		return err
+	}
+	r.saved = append(r.saved, restored)
+	return nil
+}
+
+func (r *savedStates) Load(string) (*Game, error) { return nil, ErrGameNotFound }
+
+func TestStore_CreateGameFromConfig_SavesSettings(t *testing.T) {
+	store := NewStore()
+	repo := &savedStates{}
+	store.SetRepository(repo)
+	cfg := GameConfig{Rounds: 1, DurationSec: 60, EmojisPerRound: DefaultEmojisPerRound, Lang: "en", AllowRepeatEmoji: false}
+	if _, err := store.CreateGameFromConfig(cfg, "hunter2"); err != nil {
+		t.Fatalf("CreateGameFromConfig: %v", err)
+	}
+	if len(repo.saved) == 0 {
+		t.Fatal("new game was not saved")
+	}
+	for i, saved := range repo.saved {
+		if !saved.IsProtected() {
+			t.Errorf("save %d stored the game without its password", i)
+		}
+		if saved.AllowRepeatEmoji {
+			t.Errorf("save %d stored AllowRepeatEmoji = true, want the form's false", i)
+		}
+	}
+}
+
+func TestStore_RecentGames(t *testing.T) {
+	s := NewStore()
+	base := time.Now().UTC()
+	var games []*Game
+	for i := range 4 {
+		g := s.CreateGame(1, time.Minute, "en", 0)
+		g.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		games = append(games, g)
+	}
+	games[3].Status = StatusFinished
+
+	got := s.RecentGames(2)
+	if len(got) != 2 || got[0] != games[2] || got[1] != games[1] {
+		t.Fatalf("RecentGames(2) = %v, want the two newest open games", got)
+	}
+	if got := s.RecentGames(10); len(got) != 3 {
+		t.Errorf("RecentGames(10) returned %d games, want 3", len(got))
+	}
+}
+
+func TestGame_UpdateCanvas_ItemSize(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	em := g.RoundEmojis[0]
+	items := []CanvasItem{{ID: "1", Emoji: em}, {ID: "2", Emoji: em, Size: 0.1}, {ID: "3", Emoji: em, Size: 9}, {ID: "4", Emoji: em, Size: 3}}
+	if ok, err := g.UpdateCanvas(g.ExplainerID, items); !ok || err != nil {
+		t.Fatalf("UpdateCanvas = %v, %v", ok, err)
+	}
+	want := []float64{defaultCanvasItemSize, minCanvasItemSize, maxCanvasItemSize, 3}
+	for i, item := range g.Snapshot(time.Now(), "").Canvas {
+		if item.Size != want[i] {
+			t.Errorf("item %s Size = %g, want %g", item.ID, item.Size, want[i])
+		}
+	}
+}
+
+func TestGame_AddSpectator(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", 0)
+	g.AddPlayer("alice")
+	watcher := g.AddSpectator("sam")
+	if err := g.Start(time.Now()); err == nil {
+		t.Fatal("Start should need two players; the spectator must not count")
+	}
+	g.AddPlayer("bob")
+	if !g.HasMinimumPlayers() || g.PlayerCount() != 2 {
+		t.Fatalf("PlayerCount = %d, want 2", g.PlayerCount())
+	}
+	now := time.Now()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	for round := 1; round <= 2; round++ {
+		if id := g.explainerForRoundLocked(round); id == watcher.ID {
+			t.Errorf("spectator picked to explain round %d", round)
+		}
+	}
+	if _, err := g.SubmitGuess(watcher.ID, g.Word, now); err != ErrSpectator {
+		t.Errorf("spectator SubmitGuess err = %v, want ErrSpectator", err)
+	}
+
+	snap := g.Snapshot(now, watcher.ID)
+	if snap.IsGuesser || !snap.IsSpectator {
+		t.Errorf("spectator snapshot IsGuesser=%t IsSpectator=%t", snap.IsGuesser, snap.IsSpectator)
+	}
+	if snap.SpectatorCount != 1 || len(snap.Players) != 2 || len(snap.Scores) != 2 {
+		t.Errorf("spectators=%d players=%d scores=%d, want 1/2/2", snap.SpectatorCount, len(snap.Players), len(snap.Scores))
+	}
+	if len(snap.Spectators) != 1 || snap.Spectators[0].Color == "" || snap.Spectators[0].Color != watcher.Color {
+		t.Errorf("Spectators = %+v, want sam in colour %s", snap.Spectators, watcher.Color)
+	}
+}
+
+func TestGame_ExtendRound(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en", DefaultEmojisPerRound)
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	if err := g.Start(start); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	now := start.Add(59 * time.Second) // one second left
+	if next, _ := g.NextTimer(now); !next.Equal(start.Add(time.Minute)) {
+		t.Fatalf("NextTimer before extend = %v, want %v", next, start.Add(time.Minute))
+	}
+
+	if err := g.ExtendRound(bob.ID, 10*time.Second, now); err == nil {
+		t.Error("non-owner ExtendRound should fail")
+	}
+	if err := g.ExtendRound(owner.ID, 10*time.Second, now); err != nil {
+		t.Fatalf("ExtendRound: %v", err)
+	}
+	want := start.Add(time.Minute + 10*time.Second)
+	if next, ok := g.NextTimer(now); !ok || !next.Equal(want) {
+		t.Errorf("NextTimer after extend = %v, %t; want %v", next, ok, want)
+	}
+	if g.AdvanceIfNeeded(start.Add(time.Minute + time.Second)) {
+		t.Error("round ended at the original deadline despite the extension")
+	}
+	if err := g.ExtendRound(owner.ID, 10*time.Second, want.Add(time.Second)); err == nil {
+		t.Error("ExtendRound after the deadline should fail")
+	}
+}
+
+func TestGame_EncodeState_RoundTrip(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", DefaultEmojisPerRound)
+	if err := g.SetPassword("secret"); err != nil {
+		t.Fatal(err)
+	}
+	alice := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now().UTC()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	g.Players[alice.ID].IsOnline = true
+	g.OnlineSpectators["stream-1"] = true
+
+	data, err := g.EncodeState()
+	if err != nil {
+		t.Fatalf("EncodeState: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("saved state holds the plaintext password: %s", data)
+	}
+	got, err := DecodeState(data)
+	if err != nil {
+		t.Fatalf("DecodeState: %v", err)
+	}
+	if !got.CheckPassword("secret") || got.CheckPassword("guess") {
+		t.Error("password hash lost in round trip")
+	}
+	if got.ID != g.ID || got.Word != g.Word || got.ExplainerID != g.ExplainerID || got.PlayerCount() != 2 {
+		t.Errorf("decoded game lost round state: %+v", got)
+	}
+	if got.Players[alice.ID].IsOnline || len(got.OnlineSpectators) != 0 {
+		t.Error("decoded game should start with everyone offline")
+	}
+}
+
+func TestValidateEmojiPool(t *testing.T) {
+	food := []string{"🍎", "🍌", "🍇", "🍓", "🍒", "🍑", "🍍", "🥝", "🍅", "🥕"}
+	many := make([]string, 0, maxEmojiPoolSize+1)
+	for r := rune(0x1f400); len(many) <= maxEmojiPoolSize; r++ {
+		many = append(many, string(r))
+	}
+	tests := []struct {
+		name    string
+		emojis  []string
+		wantLen int
+		wantErr bool
+	}{
+		{"ten distinct", food, 10, false},
+		{"duplicates collapse", append(append([]string{}, food...), "🍎", "🍌"), 10, false},
+		{"too few distinct", append(append([]string{}, food[:9]...), "🍎"), 0, true},
+		{"too many", many, 0, true},
+		{"multi-character item", append(append([]string{}, food...), "🍎🍌"), 0, true},
+		{"empty item", append(append([]string{}, food...), ""), 0, true},
+		{"ZWJ sequence and flag", append(append([]string{}, food[:8]...), "👩‍🚀", "🇳🇴"), 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateEmojiPool(tt.emojis)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEmojiPool) {
+					t.Fatalf("err = %v, want ErrInvalidEmojiPool", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateEmojiPool: %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("got %d emojis, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestGame_SetEmojiPool_UsedInRounds(t *testing.T) {
+	g := NewGame(3, time.Minute, "en", DefaultEmojisPerRound)
+	owner := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	pool := []string{"🐶", "🐱", "🐭", "🐹", "🐰", "🦊", "🐻", "🐼", "🐨", "🐯", "🦁", "🐮"}
+	if err := g.SetEmojiPool("someone-else", pool); err == nil {
+		t.Error("non-owner changed the emoji pool")
+	}
+	if err := g.SetEmojiPool(owner.ID, pool); err != nil {
+		t.Fatalf("SetEmojiPool: %v", err)
+	}
+	inPool := make(map[string]bool, len(pool))
+	for _, e := range pool {
+		inPool[e] = true
+	}
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	for i, rd := range g.RoundData {
+		if len(rd.Emojis) != DefaultEmojisPerRound {
+			t.Errorf("round %d has %d emojis, want %d", i+1, len(rd.Emojis), DefaultEmojisPerRound)
+		}
+		for _, e := range rd.Emojis {
+			if !inPool[e] {
+				t.Errorf("round %d emoji %q is not from the custom pool", i+1, e)
+			}
+		}
+	}
+	for _, e := range g.Snapshot(time.Now(), owner.ID).RoundEmojis {
+		if !inPool[e] {
+			t.Errorf("current palette emoji %q is not from the custom pool", e)
+		}
+	}
+	if err := g.SetEmojiPool(owner.ID, pool); err == nil {
+		t.Error("SetEmojiPool succeeded after the game started")
+	}
+}
+
+func TestGame_UndoCanvas(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainer := g.ExplainerID
+	emoji := g.RoundEmojis[0]
+	place := func(n int) {
+		items := make([]CanvasItem, n)
+		for i := range items {
+			items[i] = CanvasItem{ID: strconv.Itoa(i), Emoji: emoji}
+		}
+		if ok, err := g.UpdateCanvas(explainer, items); !ok || err != nil {
+			t.Fatalf("UpdateCanvas(%d items) = %v, %v", n, ok, err)
+		}
+	}
+
+	place(1)
+	place(2)
+	if err := g.ClearCanvas(explainer); err != nil {
+		t.Fatalf("ClearCanvas: %v", err)
+	}
+
+	var guesser string
+	for id := range g.Players {
+		if id != explainer {
+			guesser = id
+		}
+	}
+	if g.UndoCanvas(guesser) {
+		t.Fatal("guesser undid the explainer's canvas")
+	}
+	for _, want := range []int{2, 1, 0} {
+		if !g.UndoCanvas(explainer) {
+			t.Fatalf("UndoCanvas back to %d items returned false", want)
+		}
+		if len(g.Canvas) != want {
+			t.Errorf("canvas has %d items after undo, want %d", len(g.Canvas), want)
+		}
+	}
+	if g.UndoCanvas(explainer) {
+		t.Error("UndoCanvas succeeded with an empty history")
+	}
+}
+
+func TestGame_UndoCanvas_DepthLimit(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	for i := 0; i < maxCanvasHistory+5; i++ {
+		item := CanvasItem{ID: "1", Emoji: g.RoundEmojis[0], X: float64(i)}
+		if ok, err := g.UpdateCanvas(g.ExplainerID, []CanvasItem{item}); !ok || err != nil {
+			t.Fatalf("UpdateCanvas #%d = %v, %v", i, ok, err)
+		}
+	}
+	if len(g.CanvasHistory) != maxCanvasHistory {
+		t.Fatalf("history depth %d, want %d", len(g.CanvasHistory), maxCanvasHistory)
+	}
+	undone := 0
+	for g.UndoCanvas(g.ExplainerID) {
+		undone++
+	}
+	if undone != maxCanvasHistory {
+		t.Errorf("undid %d steps, want %d", undone, maxCanvasHistory)
+	}
+	// The oldest steps were dropped, so undo stops at update #4 (X=4).
+	if len(g.Canvas) != 1 || g.Canvas[0].X != 4 {
+		t.Errorf("canvas after undoing everything = %+v, want the item at X=4", g.Canvas)
+	}
+}
+
+func TestGame_AbandonRound(t *testing.T) {
+	tests := []struct {
+		name       string
+		points     int
+		wantPoints int
+	}{
+		{"deducts penalty", 5, 5 - abandonPenalty},
+		{"floors at zero", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(2, time.Minute, "en", DefaultEmojisPerRound)
+			g.AddPlayer("alice")
+			g.AddPlayer("bob")
+			if err := g.Start(time.Now()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			explainer := g.Players[g.ExplainerID]
+			explainer.Points = tt.points
+
+			if err := g.AbandonRound(explainer.ID, time.Now()); err != nil {
+				t.Fatalf("AbandonRound: %v", err)
+			}
+			if explainer.Points != tt.wantPoints {
+				t.Errorf("explainer points = %d, want %d", explainer.Points, tt.wantPoints)
+			}
+			if g.RoundEndReason != "abandoned" || g.TimedRounds.RoundEndedAt.IsZero() {
+				t.Errorf("round not ended as abandoned: reason %q", g.RoundEndReason)
+			}
+			if err := g.AbandonRound(explainer.ID, time.Now()); !errors.Is(err, ErrRoundEnded) {
+				t.Errorf("second AbandonRound = %v, want ErrRoundEnded", err)
+			}
+		})
+	}
+}
+
+func TestGame_AbandonRound_GuessDuringCooldownDoesNotScore(t *testing.T) {
+	g := NewGame(2, time.Minute, "en", DefaultEmojisPerRound)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+	if err := g.AbandonRound(g.ExplainerID, now); err != nil {
+		t.Fatalf("AbandonRound: %v", err)
+	}
+
+	ok, err := g.SubmitGuess(guesser.ID, g.Word, now.Add(time.Second))
+	if ok || err != nil {
+		t.Errorf("guess after abandon = %t, %v; want false, nil", ok, err)
+	}
+	if guesser.Points != 0 {
+		t.Errorf("guesser scored %d points after the round was abandoned", guesser.Points)
+	}
+	if g.RoundEndReason != "abandoned" {
+		t.Errorf("RoundEndReason = %q, want abandoned", g.RoundEndReason)
+	}
+}
+
+func TestGame_AbandonRound_RejectsNonExplainer(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	if err := g.AbandonRound("anyone", time.Now()); err == nil {
+		t.Error("AbandonRound in the lobby should fail")
+	}
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	for id := range g.Players {
+		if id == g.ExplainerID {
+			continue
+		}
+		if err := g.AbandonRound(id, time.Now()); !errors.Is(err, ErrNotExplainer) {
+			t.Errorf("guesser AbandonRound = %v, want ErrNotExplainer", err)
+		}
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		t.Error("a rejected AbandonRound ended the round")
+	}
+}
+
+func TestGame_SkipWord(t *testing.T) {
+	tests := []struct {
+		name       string
+		points     int
+		wantPoints int
+	}{
+		{"deducts penalty", 5, 5 - DefaultSkipPenalty},
+		{"floors at zero", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+			g.AddPlayer("alice")
+			g.AddPlayer("bob")
+			now := time.Now()
+			if err := g.Start(now); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			explainer := g.Players[g.ExplainerID]
+			explainer.Points = tt.points
+			g.Canvas = []CanvasItem{{ID: "1", Emoji: g.RoundEmojis[0]}}
+			g.RevealedIndices = []int{0}
+			spare := g.RoundData[0].Spare
+
+			if err := g.SkipWord(explainer.ID, now); err != nil {
+				t.Fatalf("SkipWord: %v", err)
+			}
+			if g.Word != spare {
+				t.Errorf("Word = %q, want spare %q", g.Word, spare)
+			}
+			if len(g.Canvas) != 0 || len(g.RevealedIndices) != 0 {
+				t.Errorf("canvas %v and revealed %v not reset", g.Canvas, g.RevealedIndices)
+			}
+			if explainer.Points != tt.wantPoints {
+				t.Errorf("Points = %d, want %d", explainer.Points, tt.wantPoints)
+			}
+			if err := g.SkipWord(explainer.ID, now); err == nil {
+				t.Error("second skip in the same round succeeded")
+			}
+		})
+	}
+}
+
+func TestGame_SkipWord_RejectsNonExplainer(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	now := time.Now()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	word := g.Word
+	for id := range g.Players {
+		if id == g.ExplainerID {
+			continue
+		}
+		if err := g.SkipWord(id, now); err == nil {
+			t.Error("guesser skipped the word")
+		}
+	}
+	if g.Word != word || g.SkipUsed {
+		t.Errorf("rejected skip changed the round: word %q → %q, SkipUsed %v", word, g.Word, g.SkipUsed)
+	}
+}
+
+func TestStore_WithMetrics(t *testing.T) {
+	c := metrics.New("test")
+	store := NewStore(WithMetrics(c))
+	store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	if snap := c.Snapshot(); snap.GamesCreated != 2 || snap.ActiveGames != 2 {
+		t.Errorf("snapshot = %+v, want 2 games created and active", snap)
+	}
+}
+
+func TestGame_GuessHistory(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+	word := g.Word
+	_, _ = g.SubmitGuess(guesser.ID, " nope ", now)
+	_, _ = g.SubmitGuess(guesser.ID, word, now.Add(time.Second))
+
+	history := g.Guesses()
+	if len(history) != 2 {
+		t.Fatalf("len(GuessHistory) = %d, want 2", len(history))
+	}
+	wrong, right := history[0], history[1]
+	if wrong.Correct || wrong.Guess != "nope" || wrong.PlayerID != guesser.ID || wrong.PlayerName != guesser.Username || wrong.Round != 1 {
+		t.Errorf("wrong guess = %+v", wrong)
+	}
+	if !right.Correct || right.Guess != word || !right.At.Equal(now.Add(time.Second)) {
+		t.Errorf("right guess = %+v, want %q correct", right, word)
+	}
+	if recent := g.Snapshot(now.Add(time.Second), "").RecentGuesses; len(recent) != 2 || recent[1] != right {
+		t.Errorf("RecentGuesses = %+v, want both attempts oldest first", recent)
+	}
+}
+
+func TestGame_GuessHistory_Capped(t *testing.T) {
+	g := NewGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob.ID
+	if g.ExplainerID == bob.ID {
+		guesser = g.JoinOrder[0]
+	}
+	total := maxGuessHistory + 7
+	for i := range total {
+		_, _ = g.SubmitGuess(guesser, "wrong"+strconv.Itoa(i), now)
+	}
+
+	history := g.Guesses()
+	if len(history) != maxGuessHistory {
+		t.Fatalf("len(GuessHistory) = %d, want %d", len(history), maxGuessHistory)
+	}
+	if first := history[0].Guess; first != "wrong7" {
+		t.Errorf("oldest kept guess = %q, want wrong7", first)
+	}
+	recent := g.Snapshot(now, "").RecentGuesses
+	if len(recent) != recentGuesses || recent[len(recent)-1].Guess != "wrong"+strconv.Itoa(total-1) {
+		t.Errorf("RecentGuesses = %+v, want the last %d", recent, recentGuesses)
+	}
+}
+
+func TestGuessLog_LastN(t *testing.T) {
+	log := GuessLog{{Guess: "a"}, {Guess: "b"}, {Guess: "c"}}
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{0, nil},
+		{2, []string{"b", "c"}},
+		{5, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, a := range log.LastN(tt.n) {
+			got = append(got, a.Guess)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("LastN(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestStore_ListActiveGames(t *testing.T) {
+	s := NewStore()
+	lobby := s.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	running := s.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	done := s.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	lobby.CreatedAt = running.CreatedAt.Add(time.Minute)
+	running.AddPlayer("alice")
+	running.AddPlayer("bob")
+	if err := running.Start(time.Now().UTC()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	done.Status = StatusFinished
+
+	games := s.ListActiveGames()
+	if len(games) != 2 {
+		t.Fatalf("ListActiveGames = %+v, want 2 games", games)
+	}
+	if games[0].ID != running.ID || games[1].ID != lobby.ID {
+		t.Errorf("order %q, %q; want oldest first", games[0].ID, games[1].ID)
+	}
+	if got := games[0]; got.Status != StatusInProgress || got.PlayerCount != 2 || got.CurrentRound != 1 {
+		t.Errorf("summary %+v", got)
+	}
+}
+
+func TestGame_ExtendRound_KeepsScoringWithinMaximum(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en", 0)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	if err := g.Start(start); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+	if err := g.ExtendRound(alice.ID, 30*time.Second, start); err != nil {
+		t.Fatalf("ExtendRound: %v", err)
+	}
+	if ok, _ := g.SubmitGuess(guesser.ID, g.Word, start.Add(time.Second)); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	if guesser.Points != 10 {
+		t.Errorf("guesser earned %d points, want the 10-point maximum", guesser.Points)
+	}
+	if snap := g.Snapshot(start.Add(time.Second), ""); snap.RoundDuration != 90*time.Second || !snap.RoundStarted.Equal(start) {
+		t.Errorf("snapshot round %v from %v, want 90s from the real start", snap.RoundDuration, snap.RoundStarted)
+	}
+}
+
+func TestGame_ExtendRound_RepeatedIsCapped(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en", 0)
+	owner := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(start); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	var granted int
+	for i := 0; i < 10; i++ {
+		if g.ExtendRound(owner.ID, 30*time.Second, start) == nil {
+			granted++
+		}
+	}
+	if granted != 2 {
+		t.Errorf("%d of 10 extends succeeded, want 2 (realtime.MaxExtra is a minute)", granted)
+	}
+	want := start.Add(time.Minute + realtime.MaxExtra)
+	if next, _ := g.NextTimer(start.Add(50 * time.Second)); !next.Equal(want) {
+		t.Errorf("deadline %v, want %v", next, want)
+	}
+}
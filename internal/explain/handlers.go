@@ -4,17 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/a-h/templ"
 	"github.com/go-chi/chi/v5"
 
+	"dagame/internal/admin"
 	"dagame/internal/explain/viewmodel"
+	"dagame/pkg/ratelimit"
+	"dagame/pkg/realtime"
 	explainviews "dagame/views/explain"
 )
 
@@ -23,35 +29,89 @@ const cookiePrefix = "explain_player"
 // Handler holds the store and serves HTTP.
 type Handler struct {
 	store *Store
+
+	guessLimits *ratelimit.Keyed // game ID -> player ID -> bucket
 }
 
 // NewHandler returns a new handler for the explain game.
 func NewHandler(store *Store) *Handler {
-	return &Handler{store: store}
+	h := &Handler{
+		store:       store,
+		guessLimits: ratelimit.NewKeyed(ratelimit.GuessesPerSecond, ratelimit.GuessBurst),
+	}
+	// Each round starts with full buckets.
+	store.OnRoundAdvance(h.guessLimits.Clear)
+	return h
 }
 
 // RegisterRoutes mounts explain routes on r.
 func (h *Handler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.home)
 	r.Post("/games", h.createGame)
+	r.Group(func(r chi.Router) {
+		r.Use(admin.Require)
+		r.Get("/admin/stats", h.adminStats)
+		r.Get("/admin/games", h.adminGames)
+	})
+	r.Get("/overlay/game/{id}", h.overlay)
+	r.Get("/embed/game/{id}", h.embed)
 	r.Route("/game/{id}", func(r chi.Router) {
 		r.Get("/", h.gamePage)
 		r.Get("/lobby", h.lobbyFragment)
 		r.Post("/join", h.joinGame)
 		r.Post("/start", h.startGame)
+		r.Post("/ready", h.readyUp)
 		r.Get("/stream", h.stream)
 		r.Get("/round", h.roundFragment)
 		r.Get("/canvas", h.canvasFragment)
 		r.Get("/players", h.playersFragment)
 		r.Get("/scores", h.scoresFragment)
+		r.Get("/scores/json", h.scoresJSON)
+		r.Get("/replay.json", h.replayJSON)
+		r.Get("/guesses", h.guessesJSON)
+		r.Get("/state.json", h.stateJSON)
+		r.Get("/stats", h.gameStats)
 		r.Get("/wordhint", h.wordHintFragment)
 		r.Post("/canvas", h.updateCanvas)
+		r.Delete("/canvas", h.clearCanvas)
+		r.Post("/canvas/undo", h.undoCanvas)
 		r.Post("/guess", h.submitGuess)
+		r.Post("/react", h.react)
+		r.Post("/abandon", h.abandonRound)
+		r.Post("/skip", h.skipWord)
+		r.Post("/extend", h.extendRound)
+		r.Post("/hint", h.useHintToken)
+		r.Post("/emojis", h.setEmojiPool)
 	})
 }
 
+var langLabels = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"no": "Norwegian",
+}
+
+// recentGamesLimit caps the "Join ongoing game" list on the home page.
+const recentGamesLimit = 5
+
 func (h *Handler) home(w http.ResponseWriter, r *http.Request) {
-	renderPage(w, r.Context(), explainviews.HomePage())
+	langs := SupportedLanguages()
+	opts := make([]viewmodel.LanguageOption, 0, len(langs))
+	for _, code := range langs {
+		label := code
+		if l, ok := langLabels[code]; ok {
+			label = l
+		}
+		opts = append(opts, viewmodel.LanguageOption{Code: code, Label: label})
+	}
+	recent := h.store.RecentGames(recentGamesLimit)
+	summaries := make([]viewmodel.GameSummary, 0, len(recent))
+	now := time.Now().UTC()
+	for _, g := range recent {
+		snap := g.Snapshot(now, "")
+		summaries = append(summaries, viewmodel.GameSummary{ID: snap.ID, PlayerCount: len(snap.Players), Status: snap.Status, Lang: snap.Lang})
+	}
+	renderPage(w, r.Context(), explainviews.HomePage(opts, summaries))
 }
 
 func (h *Handler) createGame(w http.ResponseWriter, r *http.Request) {
@@ -62,6 +122,14 @@ func (h *Handler) createGame(w http.ResponseWriter, r *http.Request) {
 	rounds := parseInt(r.FormValue("rounds"), 3)
 	durationSec := parseInt(r.FormValue("duration"), 90)
 	emojis := parseInt(r.FormValue("emojis"), DefaultEmojisPerRound)
+	lang := strings.TrimSpace(r.FormValue("lang"))
+	if lang == "" {
+		lang = "en"
+	}
+	if !slices.Contains(SupportedLanguages(), lang) {
+		http.Error(w, "unsupported language", http.StatusBadRequest)
+		return
+	}
 	if rounds < 1 {
 		rounds = 1
 	}
@@ -74,13 +142,28 @@ func (h *Handler) createGame(w http.ResponseWriter, r *http.Request) {
 	if durationSec > 300 {
 		durationSec = 300
 	}
-	if emojis < 4 {
-		emojis = 4
+	if emojis < MinEmojisPerRound {
+		emojis = MinEmojisPerRound
+	}
+	if emojis > MaxEmojisPerRound {
+		emojis = MaxEmojisPerRound
+	}
+	cfg := GameConfig{Rounds: rounds, DurationSec: durationSec, EmojisPerRound: emojis, Lang: lang,
+		AllowRepeatEmoji: r.FormValue("allow_repeat_emoji") != "false"}
+	if err := cfg.Validate(maxSessionSeconds()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	password := r.FormValue("password")
+	if len(password) > MaxPasswordLength {
+		http.Error(w, "password is too long", http.StatusBadRequest)
+		return
 	}
-	if emojis > 20 {
-		emojis = 20
+	g, err := h.store.CreateGameFromConfig(cfg, password)
+	if err != nil {
+		http.Error(w, "could not set password", http.StatusInternalServerError)
+		return
 	}
-	g := h.store.CreateGame(rounds, time.Duration(durationSec)*time.Second, "en", emojis)
 	http.Redirect(w, r, "/game/"+g.ID, http.StatusSeeOther)
 }
 
@@ -97,20 +180,27 @@ func (h *Handler) gamePage(w http.ResponseWriter, r *http.Request) {
 		playerName, hasPlayer = g.PlayerName(playerID)
 	}
 	snap := g.Snapshot(time.Now().UTC(), playerID)
-	isOwner := g.IsOwner(playerID)
-	showStart := hasPlayer && isOwner && snap.Status == StatusLobby && len(g.Players) >= MinPlayers
+	showStart := hasPlayer && canStart(snap)
 
 	data := viewmodel.GamePageData{
-		GameID:     gameID,
-		InviteURL:  buildInviteURL(r, gameID),
-		HasPlayer:  hasPlayer,
-		PlayerName: playerName,
-		PlayerID:   playerID,
-		Snap:       snapToVM(snap, showStart, len(g.Players), playerName),
+		GameID:      gameID,
+		InviteURL:   buildInviteURL(r, gameID),
+		IsProtected: snap.IsProtected,
+		HasPlayer:   hasPlayer,
+		PlayerName:  playerName,
+		PlayerID:    playerID,
+		Snap:        snapToVM(snap, showStart, len(snap.Players), playerName),
 	}
 	renderPage(w, r.Context(), explainviews.GamePage(data))
 }
 
+// canStart reports whether the snapshot's viewer may start the game: the
+// owner, still in the lobby, with enough players. Deciding from the snapshot
+// keeps the start button consistent with the rest of the render.
+func canStart(snap Snapshot) bool {
+	return snap.IsOwner && snap.Status == StatusLobby && len(snap.Players) >= MinPlayers
+}
+
 func (h *Handler) lobbyFragment(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	g, ok := h.store.GetGame(gameID)
@@ -120,10 +210,8 @@ func (h *Handler) lobbyFragment(w http.ResponseWriter, r *http.Request) {
 	}
 	playerID := getPlayerID(r, gameID)
 	playerName, hasPlayer := g.PlayerName(playerID)
-	isOwner := g.IsOwner(playerID)
 	snap := g.Snapshot(time.Now().UTC(), playerID)
-	showStart := hasPlayer && isOwner && snap.Status == StatusLobby && len(g.Players) >= MinPlayers
-	vm := snapToVM(snap, showStart, len(g.Players), playerName)
+	vm := snapToVM(snap, hasPlayer && canStart(snap), len(snap.Players), playerName)
 
 	renderFragment(w, r.Context(), explainviews.LobbyFragment(vm, gameID))
 }
@@ -147,7 +235,13 @@ func (h *Handler) joinGame(w http.ResponseWriter, r *http.Request) {
 	if len(username) > 20 {
 		username = username[:20]
 	}
-	p := g.AddPlayer(username)
+	if !g.CheckPassword(r.FormValue("password")) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		renderFragment(w, r.Context(), explainviews.JoinError(gameID, "Wrong password."))
+		return
+	}
+	p := g.AddPlayerAs(username, r.FormValue("role"))
 	setPlayerCookie(w, gameID, p.ID)
 	h.store.Publish(gameID, "players")
 	h.store.Publish(gameID, "lobby")
@@ -163,17 +257,17 @@ func (h *Handler) startGame(w http.ResponseWriter, r *http.Request) {
 	}
 	playerID := getPlayerID(r, gameID)
 	if playerID == "" {
-		log.Printf("[explain] start: no player cookie for game %s", gameID)
+		slog.Warn("start: no player cookie", slog.String("gameID", gameID))
 		http.Error(w, "not a player", http.StatusForbidden)
 		return
 	}
 	if !g.IsOwner(playerID) {
-		log.Printf("[explain] start: player %s is not owner of game %s", playerID, gameID)
+		slog.Warn("start: player is not owner", slog.String("gameID", gameID), slog.String("playerID", playerID))
 		http.Error(w, "not the owner", http.StatusForbidden)
 		return
 	}
 	if err := g.Start(time.Now().UTC()); err != nil {
-		log.Printf("[explain] start: %v", err)
+		slog.Warn("start", slog.String("gameID", gameID), slog.Any("err", err))
 		http.Error(w, err.Error(), http.StatusConflict)
 		return
 	}
@@ -188,6 +282,40 @@ func (h *Handler) startGame(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// readyUp marks the current player ready; the last ready player starts the game.
+func (h *Handler) readyUp(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "not a player", http.StatusForbidden)
+		return
+	}
+	err := g.ReadyUp(playerID, time.Now().UTC())
+	if err != nil && !errors.Is(err, ErrAutoStarted) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "lobby")
+	if errors.Is(err, ErrAutoStarted) {
+		h.store.EnsureRoundLoop(gameID, g)
+		h.store.Publish(gameID, "round")
+		h.store.Publish(gameID, "canvas")
+		h.store.Publish(gameID, "wordhint")
+		h.store.Publish(gameID, "players")
+		h.store.Publish(gameID, "scores")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// streamEvents are the events stream renders a fragment for; it subscribes to
+// just these so unrelated events never wake it.
+var streamEvents = []string{"lobby", "round", "canvas", "wordhint", "players", "scores", "reaction", "timer"}
+
 func (h *Handler) stream(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	g, ok := h.store.GetGame(gameID)
@@ -195,74 +323,123 @@ func (h *Handler) stream(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	flusher, ok := w.(http.Flusher)
+	out, ok := realtime.OpenEventSink(w, r)
 	if !ok {
-		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
 		return
 	}
+	defer out.Close()
+	// Ping before any work so the client sees a live stream right away.
+	out.KeepAlive()
+	out.Flush()
 	playerID := getPlayerID(r, gameID)
-	playerName, _ := g.PlayerName(playerID)
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
+	playerName, isPlayer := g.PlayerName(playerID)
 
 	hub := h.store.Broadcaster(gameID)
-	sub := hub.Subscribe()
+	sub := hub.SubscribeWithFilter(streamEvents...)
 	defer hub.Unsubscribe(sub)
 
+	if isPlayer {
+		g.MarkPlayerOnline(playerID)
+		h.store.Publish(gameID, "players")
+		defer func() {
+			g.MarkPlayerOffline(playerID)
+			h.store.Publish(gameID, "players")
+		}()
+	} else {
+		spectatorID := newID()
+		g.MarkSpectatorOnline(spectatorID)
+		h.store.Publish(gameID, "players")
+		defer func() {
+			g.MarkSpectatorOffline(spectatorID)
+			h.store.Publish(gameID, "players")
+		}()
+	}
+
 	ctx := r.Context()
 
 	sendAll := func() {
 		snap := g.Snapshot(time.Now().UTC(), playerID)
-		showStart := playerID != "" && g.IsOwner(playerID) && snap.Status == StatusLobby && len(snap.Players) >= MinPlayers
-		vm := snapToVM(snap, showStart, len(snap.Players), playerName)
+		vm := snapToVM(snap, canStart(snap), len(snap.Players), playerName)
 		lobbyHTML := ""
 		if snap.Status == StatusLobby {
 			lobbyHTML = renderComponent(ctx, explainviews.LobbyFragment(vm, gameID))
 		}
-		writeSSE(w, "lobby", lobbyHTML)
-		writeSSE(w, "round", renderComponent(ctx, explainviews.RoundFragment(vm)))
-		writeSSE(w, "canvas", renderComponent(ctx, explainviews.CanvasFragment(vm)))
-		writeSSE(w, "wordhint", renderComponent(ctx, explainviews.WordHintFragment(vm, gameID)))
-		writeSSE(w, "players", renderComponent(ctx, explainviews.PlayersFragment(vm, playerID)))
-		writeSSE(w, "scores", renderComponent(ctx, explainviews.ScoresFragment(vm)))
-		flusher.Flush()
+		out.Send("lobby", lobbyHTML)
+		out.Send("round", renderComponent(ctx, explainviews.RoundFragment(vm)))
+		out.Send("canvas", renderComponent(ctx, explainviews.CanvasFragment(vm)))
+		out.Send("wordhint", renderComponent(ctx, explainviews.WordHintFragment(vm, gameID)))
+		out.Send("players", renderComponent(ctx, explainviews.PlayersFragment(vm, playerID)))
+		out.Send("scores", renderComponent(ctx, explainviews.ScoresFragment(vm)))
+		out.Flush()
+	}
+	sendEvent := func(event string) {
+		if event == "timer" {
+			// Ticks every second per stream, so skip the full snapshot.
+			if remaining, ok := g.RoundTimeLeft(time.Now().UTC()); ok {
+				out.Send("timer", timerJSON(remaining))
+			}
+			return
+		}
+		snap := g.Snapshot(time.Now().UTC(), playerID)
+		vm := snapToVM(snap, canStart(snap), len(snap.Players), playerName)
+		switch event {
+		case "lobby":
+			lobbyHTML := ""
+			if snap.Status == StatusLobby {
+				lobbyHTML = renderComponent(ctx, explainviews.LobbyFragment(vm, gameID))
+			}
+			out.Send("lobby", lobbyHTML)
+		case "round":
+			out.Send("round", renderComponent(ctx, explainviews.RoundFragment(vm)))
+		case "canvas":
+			out.Send("canvas", renderComponent(ctx, explainviews.CanvasFragment(vm)))
+		case "wordhint":
+			out.Send("wordhint", renderComponent(ctx, explainviews.WordHintFragment(vm, gameID)))
+		case "players":
+			out.Send("players", renderComponent(ctx, explainviews.PlayersFragment(vm, playerID)))
+		case "scores":
+			out.Send("scores", renderComponent(ctx, explainviews.ScoresFragment(vm)))
+		case "reaction":
+			out.Send("reaction", renderComponent(ctx, explainviews.ReactionFragment(vm)))
+		}
+	}
+
+	// On reconnect, replay what changed since Last-Event-ID when the history still
+	// covers it; a stale reaction is not worth re-animating.
+	lastID := hub.LastID()
+	if since, ok := realtime.LastEventID(r); ok && hub.Covers(since) {
+		for _, event := range realtime.EventNames(hub.EventsSince(since)) {
+			if event != "reaction" {
+				sendEvent(event)
+			}
+		}
+	} else {
+		sendAll()
 	}
-	sendAll()
+	out.EventID(lastID)
+	out.Retry()
+	out.Flush()
 
-	keepAlive := time.NewTicker(25 * time.Second)
+	keepAlive := time.NewTicker(realtime.Heartbeat)
 	defer keepAlive.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case event := <-sub:
-			snap := g.Snapshot(time.Now().UTC(), playerID)
-			showStart := playerID != "" && g.IsOwner(playerID) && snap.Status == StatusLobby && len(snap.Players) >= MinPlayers
-			vm := snapToVM(snap, showStart, len(snap.Players), playerName)
-			switch event {
-			case "lobby":
-				lobbyHTML := ""
-				if snap.Status == StatusLobby {
-					lobbyHTML = renderComponent(ctx, explainviews.LobbyFragment(vm, gameID))
-				}
-				writeSSE(w, "lobby", lobbyHTML)
-			case "round":
-				writeSSE(w, "round", renderComponent(ctx, explainviews.RoundFragment(vm)))
-			case "canvas":
-				writeSSE(w, "canvas", renderComponent(ctx, explainviews.CanvasFragment(vm)))
-			case "wordhint":
-				writeSSE(w, "wordhint", renderComponent(ctx, explainviews.WordHintFragment(vm, gameID)))
-			case "players":
-				writeSSE(w, "players", renderComponent(ctx, explainviews.PlayersFragment(vm, playerID)))
-			case "scores":
-				writeSSE(w, "scores", renderComponent(ctx, explainviews.ScoresFragment(vm)))
+		case <-out.Closed():
+			return
+		case event, ok := <-sub:
+			if !ok {
+				return // game deleted
 			}
-			flusher.Flush()
+			id := hub.LastID()
+			sendEvent(event)
+			out.EventID(id)
+			out.Flush()
 		case <-keepAlive.C:
-			_, _ = w.Write([]byte(": keepalive\n\n"))
-			flusher.Flush()
+			out.KeepAlive()
+			out.Flush()
 		}
 	}
 }
@@ -319,6 +496,166 @@ func (h *Handler) playersFragment(w http.ResponseWriter, r *http.Request) {
 	renderFragment(w, r.Context(), explainviews.PlayersFragment(snapToVM(snap, false, 0, pname), playerID))
 }
 
+// overlay renders the read-only OBS overlay; no player cookie is needed.
+func (h *Handler) overlay(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snap := g.Snapshot(time.Now().UTC(), "")
+	renderPage(w, r.Context(), explainviews.OverlayPage(snapToVM(snap, false, 0, "")))
+}
+
+// replayJSON serves the full event log to the owner, or to anyone after the game ends.
+func (h *Handler) replayJSON(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	events, err := g.Replay(getPlayerID(r, gameID))
+	if err != nil {
+		writeJSONStatus(w, http.StatusForbidden, map[string]any{"error": err.Error()})
+		return
+	}
+	writeJSONStatus(w, http.StatusOK, map[string]any{"game_id": gameID, "events": events})
+}
+
+// guessesJSON serves every recorded guess, right and wrong, for post-game review.
+func (h *Handler) guessesJSON(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSONStatus(w, http.StatusOK, map[string]any{"game_id": gameID, "guesses": g.Guesses()})
+}
+
+// embed renders the iframe-friendly view. Framing is limited to the same
+// origin unless EMBED_FRAME_OPTIONS=ALLOWALL opts in to cross-origin embeds.
+func (h *Handler) embed(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("EMBED_FRAME_OPTIONS")), "ALLOWALL") {
+		// X-Frame-Options has no allow-all value, so only CSP is sent.
+		w.Header().Set("Content-Security-Policy", "frame-ancestors *")
+	} else {
+		w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+		w.Header().Set("Content-Security-Policy", "frame-ancestors 'self'")
+	}
+	snap := g.Snapshot(time.Now().UTC(), "")
+	renderPage(w, r.Context(), explainviews.EmbedPage(gameID, snapToVM(snap, false, 0, "")))
+}
+
+// gameStateJSON is the public, machine-readable view of a game. It carries
+// names only: player IDs double as session cookies and must not leak.
+type gameStateJSON struct {
+	ID              string      `json:"id"`
+	Lang            string      `json:"lang"`
+	Status          string      `json:"status"`
+	CurrentRound    int         `json:"current_round"`
+	Rounds          int         `json:"rounds"`
+	ExplainerName   string      `json:"explainer,omitempty"`
+	RevealedWord    string      `json:"revealed_word"`
+	WordLength      int         `json:"word_length"`
+	TimeRemainingMs int64       `json:"time_remaining_ms"`
+	RoundWinnerName string      `json:"round_winner,omitempty"`
+	WinnerName      string      `json:"winner,omitempty"`
+	Players         []string    `json:"players"`
+	Scores          []scoreJSON `json:"scores"`
+}
+
+// stateJSON serves the current game state for assistive tech, bots and tests.
+func (h *Handler) stateJSON(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	now := time.Now().UTC()
+	snap := g.Snapshot(now, "")
+	state := gameStateJSON{
+		ID:              snap.ID,
+		Lang:            snap.Lang,
+		Status:          snap.Status,
+		CurrentRound:    snap.CurrentRound,
+		Rounds:          snap.Rounds,
+		ExplainerName:   snap.ExplainerName,
+		RevealedWord:    snap.RevealedWord,
+		WordLength:      snap.WordLength,
+		RoundWinnerName: snap.RoundWinnerName,
+		WinnerName:      snap.WinnerName,
+		Players:         make([]string, len(snap.Players)),
+		Scores:          make([]scoreJSON, len(snap.Scores)),
+	}
+	if snap.Status == StatusInProgress && snap.RoundEndedAt.IsZero() {
+		if remaining := snap.RoundStarted.Add(snap.RoundDuration).Sub(now); remaining > 0 {
+			state.TimeRemainingMs = remaining.Milliseconds()
+		}
+	}
+	for i, p := range snap.Players {
+		state.Players[i] = p.Name
+	}
+	for i, s := range snap.Scores {
+		state.Scores[i] = scoreJSON{Name: s.Name, Points: s.Points}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSONStatus(w, http.StatusOK, state)
+}
+
+type scoreJSON struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+// gameStats reports player and open stream counts for debugging stuck
+// connections.
+func (h *Handler) gameStats(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snap := g.Snapshot(time.Now().UTC(), "")
+	writeJSONStatus(w, http.StatusOK, struct {
+		Players     int    `json:"players"`
+		Connections int    `json:"connections"`
+		Status      string `json:"status"`
+	}{len(snap.Players), h.store.ActiveConnectionCount(gameID), snap.Status})
+}
+
+// scoresJSON serves the scoreboard for overlays and external leaderboards.
+// Scores are public, so no player cookie is required.
+func (h *Handler) scoresJSON(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snap := g.Snapshot(time.Now().UTC(), "")
+	scores := make([]scoreJSON, len(snap.Scores))
+	for i, s := range snap.Scores {
+		scores[i] = scoreJSON{Name: s.Name, Points: s.Points}
+	}
+	writeJSONStatus(w, http.StatusOK, struct {
+		Scores []scoreJSON `json:"scores"`
+		Winner string      `json:"winner"`
+		Status string      `json:"status"`
+		Round  int         `json:"round"`
+	}{scores, snap.WinnerName, snap.Status, snap.CurrentRound})
+}
+
 func (h *Handler) scoresFragment(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	g, ok := h.store.GetGame(gameID)
@@ -349,13 +686,45 @@ func (h *Handler) updateCanvas(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid JSON", http.StatusBadRequest)
 		return
 	}
-	if g.UpdateCanvas(playerID, items) {
+	if i, field, ok := invalidCanvasItem(items); ok {
+		writeJSONStatus(w, http.StatusUnprocessableEntity, map[string]any{
+			"error": "invalid item at index " + strconv.Itoa(i),
+			"field": field,
+		})
+		return
+	}
+	updated, err := g.UpdateCanvas(playerID, items)
+	if err != nil {
+		writeJSONStatus(w, http.StatusUnprocessableEntity, map[string]any{"error": err.Error()})
+		return
+	}
+	if updated {
 		h.store.Publish(gameID, "canvas")
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) submitGuess(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) clearCanvas(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := g.ClearCanvas(playerID); err != nil {
+		http.Error(w, err.Error(), explainerErrorStatus(err))
+		return
+	}
+	h.store.Publish(gameID, "canvas")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) undoCanvas(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	g, ok := h.store.GetGame(gameID)
 	if !ok {
@@ -364,28 +733,244 @@ func (h *Handler) submitGuess(w http.ResponseWriter, r *http.Request) {
 	}
 	playerID := getPlayerID(r, gameID)
 	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !g.UndoCanvas(playerID) {
+		http.Error(w, "nothing to undo", http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "canvas")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) submitGuess(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if _, isPlayer := g.PlayerName(playerID); !isPlayer {
 		http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 		return
 	}
+	if ok, wait := h.guessLimits.Allow(gameID, playerID, time.Now()); !ok {
+		ratelimit.TooManyRequests(w, wait, "too many guesses")
+		return
+	}
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "invalid form", http.StatusBadRequest)
 		return
 	}
 	guess := strings.TrimSpace(r.FormValue("guess"))
+	h.store.Metrics().GuessSubmitted()
 	correct, err := g.SubmitGuess(playerID, guess, time.Now().UTC())
 	if err != nil {
-		log.Printf("submit guess: %v", err)
+		slog.Warn("submit guess", slog.String("gameID", gameID), slog.String("playerID", playerID), slog.Any("err", err))
 	}
 	if correct {
+		h.guessLimits.Clear(gameID)
 		h.store.Wake(gameID)
 		h.store.Publish(gameID, "round")
 		h.store.Publish(gameID, "scores")
 		h.store.Publish(gameID, "players")
 		h.store.Publish(gameID, "wordhint")
+	} else if err == nil {
+		// Refresh everyone's guess feed.
+		h.store.Publish(gameID, "wordhint")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) react(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	if err := g.ReactToGuess(playerID, strings.TrimSpace(r.FormValue("emoji")), time.Now().UTC()); err != nil {
+		if errors.Is(err, ErrNotGuesser) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.store.Publish(gameID, "reaction")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maxExtendSeconds caps a single round extension.
+const maxExtendSeconds = 30
+
+// extendRound adds up to maxExtendSeconds to the running round; owner only.
+func (h *Handler) extendRound(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if !g.IsOwner(playerID) {
+		http.Error(w, "only the owner can extend the round", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
 	}
+	seconds := min(parseInt(r.FormValue("seconds"), 15), maxExtendSeconds)
+	if err := g.ExtendRound(playerID, time.Duration(seconds)*time.Second, time.Now().UTC()); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Wake(gameID)
+	h.store.Publish(gameID, "round")
+	h.store.Publish(gameID, "timer")
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// setEmojiPool replaces the game's emoji palette source with a JSON body
+// {"emojis": [...]}; owner only, before the game starts.
+func (h *Handler) setEmojiPool(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if !g.IsOwner(playerID) {
+		http.Error(w, "only the owner can change the emoji pool", http.StatusForbidden)
+		return
+	}
+	var body struct {
+		Emojis []string `json:"emojis"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := g.SetEmojiPool(playerID, body.Emojis); err != nil {
+		status := http.StatusConflict
+		if errors.Is(err, ErrInvalidEmojiPool) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.store.Publish(gameID, "lobby")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// skipWord swaps the explainer's word for the round's spare, once per round.
+func (h *Handler) skipWord(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := g.SkipWord(playerID, time.Now().UTC()); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "round")
+	h.store.Publish(gameID, "canvas")
+	h.store.Publish(gameID, "wordhint")
+	h.store.Publish(gameID, "scores")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) abandonRound(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := g.AbandonRound(playerID, time.Now().UTC()); err != nil {
+		http.Error(w, err.Error(), explainerErrorStatus(err))
+		return
+	}
+	h.store.Wake(gameID)
+	h.store.Publish(gameID, "round")
+	h.store.Publish(gameID, "canvas")
+	h.store.Publish(gameID, "wordhint")
+	h.store.Publish(gameID, "players")
+	h.store.Publish(gameID, "scores")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) useHintToken(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	g, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := getPlayerID(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if _, err := g.UseHintToken(playerID, time.Now().UTC()); err != nil {
+		http.Error(w, err.Error(), explainerErrorStatus(err))
+		return
+	}
+	h.store.Publish(gameID, "wordhint")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// explainerErrorStatus maps an error from an explainer-only action to 403 for
+// callers who aren't the explainer and 409 for anything else.
+func explainerErrorStatus(err error) int {
+	if errors.Is(err, ErrNotExplainer) {
+		return http.StatusForbidden
+	}
+	return http.StatusConflict
+}
+
+// invalidCanvasItem returns the index and field of the first item with an empty
+// ID or an Emoji that is not exactly one grapheme cluster.
+func invalidCanvasItem(items []CanvasItem) (index int, field string, ok bool) {
+	for i, item := range items {
+		if item.ID == "" {
+			return i, "ID", true
+		}
+		if !utf8.ValidString(item.Emoji) || !isSingleGrapheme(item.Emoji) {
+			return i, "Emoji", true
+		}
+		if item.Size != 0 && (item.Size < minCanvasItemSize || item.Size > maxCanvasItemSize) {
+			return i, "Size", true
+		}
+	}
+	return 0, "", false
+}
+
 func getPlayerID(r *http.Request, gameID string) string {
 	cookie, err := r.Cookie(cookiePrefix + "_" + gameID)
 	if err != nil {
@@ -428,19 +1013,22 @@ func parseInt(s string, fallback int) int {
 	return n
 }
 
-func writeSSE(w http.ResponseWriter, event, data string) {
-	_, _ = w.Write([]byte("event: " + event + "\n"))
-	for _, line := range strings.Split(data, "\n") {
-		_, _ = w.Write([]byte("data: " + line + "\n"))
-	}
-	_, _ = w.Write([]byte("\n"))
+// timerJSON is the "timer" event payload: {"remainingMs": N}; see Game.RoundTimeLeft.
+func timerJSON(remaining time.Duration) string {
+	return `{"remainingMs":` + strconv.FormatInt(remaining.Milliseconds(), 10) + `}`
+}
+
+func writeJSONStatus(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
 }
 
 // renderPage renders a full-page templ component to the response writer.
 func renderPage(w http.ResponseWriter, ctx context.Context, c templ.Component) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := c.Render(ctx, w); err != nil {
-		log.Printf("renderPage: %v", err)
+		slog.Error("render page", slog.Any("err", err))
 	}
 }
 
@@ -448,7 +1036,7 @@ func renderPage(w http.ResponseWriter, ctx context.Context, c templ.Component) {
 func renderFragment(w http.ResponseWriter, ctx context.Context, c templ.Component) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := c.Render(ctx, w); err != nil {
-		log.Printf("renderFragment: %v", err)
+		slog.Error("render fragment", slog.Any("err", err))
 	}
 }
 
@@ -456,7 +1044,7 @@ func renderFragment(w http.ResponseWriter, ctx context.Context, c templ.Componen
 func renderComponent(ctx context.Context, c templ.Component) string {
 	var buf bytes.Buffer
 	if err := c.Render(ctx, &buf); err != nil {
-		log.Printf("renderComponent: %v", err)
+		slog.Error("render component", slog.Any("err", err))
 	}
 	return buf.String()
 }
@@ -465,43 +1053,72 @@ func renderComponent(ctx context.Context, c templ.Component) string {
 func snapToVM(snap Snapshot, showStart bool, playerCount int, currentPlayerName string) viewmodel.SnapData {
 	players := make([]viewmodel.PlayerInfo, len(snap.Players))
 	for i, p := range snap.Players {
-		players[i] = viewmodel.PlayerInfo{ID: p.ID, Name: p.Name, IsExplainer: p.IsExplainer}
+		players[i] = viewmodel.PlayerInfo{ID: p.ID, Name: p.Name, IsExplainer: p.IsExplainer, Solved: p.Solved, IsOnline: p.IsOnline, Ready: p.Ready, Color: p.Color}
 	}
 	scores := make([]viewmodel.ScoreEntry, len(snap.Scores))
 	for i, s := range snap.Scores {
-		scores[i] = viewmodel.ScoreEntry{Name: s.Name, Points: s.Points}
+		scores[i] = viewmodel.ScoreEntry{Name: s.Name, Color: s.Color, Points: s.Points, Delta: s.Delta}
+	}
+	roundWinners := make([]viewmodel.RoundWinner, len(snap.RoundWinners))
+	for i, w := range snap.RoundWinners {
+		roundWinners[i] = viewmodel.RoundWinner{Name: w.Name, Rank: w.Rank, Points: w.Points}
 	}
 	canvas := make([]viewmodel.CanvasItem, len(snap.Canvas))
 	for i, c := range snap.Canvas {
-		canvas[i] = viewmodel.CanvasItem{ID: c.ID, Emoji: c.Emoji, X: c.X, Y: c.Y}
+		canvas[i] = viewmodel.CanvasItem{ID: c.ID, Emoji: c.Emoji, X: c.X, Y: c.Y, Size: c.Size}
 	}
-	var roundStartedMs, nextRoundAtMs int64
+	guessFeed := make([]viewmodel.GuessFeedEntry, len(snap.RecentGuesses))
+	for i, a := range snap.RecentGuesses {
+		guessFeed[i] = viewmodel.GuessFeedEntry{Name: a.PlayerName, Guess: a.Guess, Correct: a.Correct}
+	}
+	var roundStartedMs, nextRoundAtMs, reactionAtMs int64
 	if !snap.RoundStarted.IsZero() {
 		roundStartedMs = snap.RoundStarted.UnixMilli()
 	}
 	if !snap.NextRoundAt.IsZero() {
 		nextRoundAtMs = snap.NextRoundAt.UnixMilli()
 	}
+	if !snap.Reaction.At.IsZero() {
+		reactionAtMs = snap.Reaction.At.UnixMilli()
+	}
 	return viewmodel.SnapData{
-		Status:           snap.Status,
-		CurrentRound:     snap.CurrentRound,
-		Rounds:           snap.Rounds,
-		RoundDurationSec: int(snap.RoundDuration.Seconds()),
-		RoundStartedMs:   roundStartedMs,
-		NextRoundAtMs:    nextRoundAtMs,
-		ExplainerName:    snap.ExplainerName,
-		RoundWinnerName:  snap.RoundWinnerName,
-		WinnerName:       snap.WinnerName,
-		IsExplainer:      snap.IsExplainer,
-		IsGuesser:        snap.IsGuesser,
-		Word:             snap.Word,
-		RevealedWord:     snap.RevealedWord,
-		WordLength:       snap.WordLength,
-		Canvas:           canvas,
-		RoundEmojis:      snap.RoundEmojis,
-		Players:          players,
-		Scores:           scores,
+		Lang:              snap.Lang,
+		Status:            snap.Status,
+		CurrentRound:      snap.CurrentRound,
+		Rounds:            snap.Rounds,
+		RoundDurationSec:  int(snap.RoundDuration.Seconds()),
+		RoundStartedMs:    roundStartedMs,
+		RoundDurationMs:   snap.RoundDuration.Milliseconds(),
+		NextRoundAtMs:     nextRoundAtMs,
+		ExplainerName:     snap.ExplainerName,
+		NextExplainerName: snap.NextExplainerName,
+		RoundWinners:      roundWinners,
+		RoundWinnerName:   snap.RoundWinnerName,
+		RoundEndReason:    snap.RoundEndReason,
+		HintTokens:        snap.HintTokens,
+		SkipUsed:          snap.SkipUsed,
+		IsLastRound:       snap.IsLastRound,
+		WinnerName:        snap.WinnerName,
+		IsExplainer:       snap.IsExplainer,
+		IsGuesser:         snap.IsGuesser,
+		Word:              snap.Word,
+		RevealedWord:      snap.RevealedWord,
+		WordLength:        snap.WordLength,
+		Canvas:            canvas,
+		RoundEmojis:       snap.RoundEmojis,
+		AllowRepeatEmoji:  snap.AllowRepeatEmoji,
+		Players:           players,
+		SpectatorCount:    snap.SpectatorCount,
+		ObserverCount:     snap.ObserverCount,
+		Scores:            scores,
+		ReactionEmoji:     snap.Reaction.Emoji,
+		ReactionName:      snap.ReactionName,
+		ReactionAtMs:      reactionAtMs,
+		GuessFeed:         guessFeed,
 		ShowStart:         showStart,
+		IsReady:           snap.IsReady,
+		IsSpectator:       snap.IsSpectator,
+		IsOwner:           snap.IsOwner,
 		PlayerCount:       playerCount,
 		MinPlayers:        MinPlayers,
 		CurrentPlayerName: currentPlayerName,
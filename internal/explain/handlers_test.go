@@ -0,0 +1,578 @@
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/pkg/ratelimit"
+)
+
+func TestHandler_ClearCanvas(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainerID := g.ExplainerID
+	if ok, err := g.UpdateCanvas(explainerID, []CanvasItem{{ID: "1", Emoji: g.RoundEmojis[0], X: 10, Y: 20}}); !ok || err != nil {
+		t.Fatalf("UpdateCanvas = %v, %v for explainer", ok, err)
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	clear := func(playerID string) int {
+		req := httptest.NewRequest(http.MethodDelete, "/game/"+g.ID+"/canvas", nil)
+		req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	guesserID := g.JoinOrder[0]
+	if guesserID == explainerID {
+		guesserID = g.JoinOrder[1]
+	}
+	if code := clear(guesserID); code != http.StatusForbidden {
+		t.Errorf("guesser clear: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := clear(explainerID); code != http.StatusNoContent {
+		t.Fatalf("status %d, want %d", code, http.StatusNoContent)
+	}
+	snap := g.Snapshot(time.Now(), explainerID)
+	if len(snap.Canvas) != 0 {
+		t.Errorf("canvas has %d items after clear, want 0", len(snap.Canvas))
+	}
+
+	if ok, _ := g.SubmitGuess(guesserID, g.Word, time.Now()); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	if code := clear(explainerID); code != http.StatusConflict {
+		t.Errorf("clear during cooldown: status %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestHandler_UseHintToken(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainerID := g.ExplainerID
+	guesserID := g.JoinOrder[0]
+	if guesserID == explainerID {
+		guesserID = g.JoinOrder[1]
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	hint := func(playerID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/hint", nil)
+		req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := hint(guesserID); code != http.StatusForbidden {
+		t.Errorf("guesser hint: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := hint(explainerID); code != http.StatusNoContent {
+		t.Fatalf("explainer hint: status %d, want %d", code, http.StatusNoContent)
+	}
+	if ok, _ := g.SubmitGuess(guesserID, g.Word, time.Now()); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	tokens := g.Snapshot(time.Now(), explainerID).HintTokens
+	if code := hint(explainerID); code != http.StatusConflict {
+		t.Errorf("hint during cooldown: status %d, want %d", code, http.StatusConflict)
+	}
+	if got := g.Snapshot(time.Now(), explainerID).HintTokens; got != tokens {
+		t.Errorf("cooldown hint spent a token: %d -> %d", tokens, got)
+	}
+}
+
+func TestHandler_AbandonRound(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(2, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	explainerID := g.ExplainerID
+	guesserID := g.JoinOrder[0]
+	if guesserID == explainerID {
+		guesserID = g.JoinOrder[1]
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	abandon := func(playerID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/abandon", nil)
+		req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := abandon(guesserID); code != http.StatusForbidden {
+		t.Errorf("guesser abandon: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := abandon(explainerID); code != http.StatusNoContent {
+		t.Fatalf("explainer abandon: status %d, want %d", code, http.StatusNoContent)
+	}
+	if code := abandon(explainerID); code != http.StatusConflict {
+		t.Errorf("abandon during cooldown: status %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestHandler_CreateGame_RejectsLongSession(t *testing.T) {
+	t.Setenv("MAX_SESSION_SECONDS", "600")
+	store := NewStore()
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/games", strings.NewReader("rounds=10&duration=120"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(rec.Body.String(), "at most 10 minutes") {
+		t.Errorf("body %q does not state the limit", rec.Body.String())
+	}
+}
+
+func TestHandler_CreateGame_RejectsUnknownLanguage(t *testing.T) {
+	store := NewStore()
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/games", strings.NewReader("lang=xx"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandler_Fragments_ColorPlayerNames(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	alice := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	for _, path := range []string{"/players", "/scores"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, want 200", path, rec.Code)
+		}
+		if want := `<span style="color:` + alice.Color + `;">alice</span>`; !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s: body missing %s:\n%s", path, want, rec.Body.String())
+		}
+	}
+}
+
+func TestHandler_UpdateCanvas_RejectsInvalidItem(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	body := `[{"ID":"1","Emoji":"🍎","X":1,"Y":2},{"ID":"2","Emoji":"🍎🍌","X":3,"Y":4}]`
+	req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/canvas", strings.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: g.ExplainerID})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	var resp struct{ Error, Field string }
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != "invalid item at index 1" || resp.Field != "Emoji" {
+		t.Errorf("got %+v", resp)
+	}
+}
+
+func TestHandler_ScoresJSON(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/scores/json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type %q, want application/json", ct)
+	}
+	var body struct {
+		Scores []struct {
+			Name   string `json:"name"`
+			Points int    `json:"points"`
+		} `json:"scores"`
+		Winner string `json:"winner"`
+		Status string `json:"status"`
+		Round  int    `json:"round"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Scores) != 1 || body.Scores[0].Name != "alice" || body.Status != StatusLobby {
+		t.Errorf("got %+v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/missing/scores/json", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown game: status %d, want 404", rec.Code)
+	}
+}
+
+func TestHandler_Embed_FrameHeaders(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/embed/game/"+g.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("X-Frame-Options"); got != "SAMEORIGIN" {
+		t.Errorf("X-Frame-Options %q, want SAMEORIGIN", got)
+	}
+	if got := rec.Header().Get("Content-Security-Policy"); got != "frame-ancestors 'self'" {
+		t.Errorf("Content-Security-Policy %q", got)
+	}
+	if strings.Contains(rec.Body.String(), "/join") {
+		t.Error("embed page should not contain the join form")
+	}
+
+	t.Setenv("EMBED_FRAME_OPTIONS", "ALLOWALL")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/embed/game/"+g.ID, nil))
+	if got := rec.Header().Get("X-Frame-Options"); got != "" {
+		t.Errorf("X-Frame-Options %q, want none", got)
+	}
+	if got := rec.Header().Get("Content-Security-Policy"); got != "frame-ancestors *" {
+		t.Errorf("Content-Security-Policy %q, want frame-ancestors *", got)
+	}
+}
+
+func TestHandler_GamePage_LangAttribute(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "es", DefaultEmojisPerRound)
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/", nil))
+	if !strings.Contains(rec.Body.String(), `<html lang="es">`) {
+		t.Errorf("game page does not declare lang=\"es\"")
+	}
+}
+
+func TestTimerJSON(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	if _, ok := g.RoundTimeLeft(time.Now().UTC()); ok {
+		t.Error("RoundTimeLeft in the lobby should report !ok")
+	}
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	remaining, ok := g.RoundTimeLeft(now.Add(20 * time.Second))
+	if !ok {
+		t.Fatal("RoundTimeLeft during a round should report ok")
+	}
+	var body struct {
+		RemainingMs int64 `json:"remainingMs"`
+	}
+	if err := json.Unmarshal([]byte(timerJSON(remaining)), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.RemainingMs != 40000 {
+		t.Errorf("remainingMs = %d, want 40000", body.RemainingMs)
+	}
+	if remaining, _ := g.RoundTimeLeft(now.Add(time.Hour)); remaining != 0 {
+		t.Errorf("remaining past the deadline = %v, want 0", remaining)
+	}
+}
+
+func TestHandler_Stream_PingsFirst(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // the handler writes its initial burst, then returns
+	req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/stream", nil).WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); !strings.HasPrefix(body, ": keepalive\n\n") {
+		t.Errorf("stream starts with %q, want a comment ping", body[:min(len(body), 40)])
+	}
+}
+
+func TestHandler_UpdateCanvas_RejectsOutOfRangeSize(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	body := `[{"ID":"1","Emoji":"` + g.RoundEmojis[0] + `","X":1,"Y":2,"Size":5}]`
+	req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/canvas", strings.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: g.ExplainerID})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	var resp struct{ Error, Field string }
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Field != "Size" {
+		t.Errorf("got %+v, want field Size", resp)
+	}
+}
+
+func TestHandler_SubmitGuess_RateLimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int // requests round-robin across this many players
+	}{
+		{"single player", 1},
+		{"two players have separate buckets", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+			var ids []string
+			for i := range tt.players {
+				ids = append(ids, g.AddPlayer("p"+strconv.Itoa(i)).ID)
+			}
+
+			r := chi.NewRouter()
+			h := NewHandler(store)
+			h.RegisterRoutes(r)
+			guess := func(playerID string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/guess", strings.NewReader("guess=zzz"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: playerID})
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				return rec
+			}
+
+			for i := range 20 * tt.players {
+				rec := guess(ids[i%tt.players])
+				nth := i/tt.players + 1
+				if nth <= ratelimit.GuessBurst {
+					if rec.Code != http.StatusNoContent {
+						t.Fatalf("request %d for player %d: status %d, want 204", nth, i%tt.players, rec.Code)
+					}
+					continue
+				}
+				if rec.Code != http.StatusTooManyRequests {
+					t.Fatalf("request %d for player %d: status %d, want 429", nth, i%tt.players, rec.Code)
+				}
+				if rec.Header().Get("Retry-After") == "" {
+					t.Fatal("429 without Retry-After")
+				}
+			}
+
+			h.guessLimits.Clear(g.ID)
+			if rec := guess(ids[0]); rec.Code != http.StatusNoContent {
+				t.Errorf("status %d after clearing the game's limits, want 204", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_JoinGame_Password(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	if err := g.SetPassword("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	join := func(password string) *httptest.ResponseRecorder {
+		form := "username=alice&password=" + password
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/join", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := join("wrong"); rec.Code != http.StatusForbidden {
+		t.Errorf("wrong password: status %d, want 403", rec.Code)
+	}
+	if n := g.PlayerCount(); n != 0 {
+		t.Fatalf("PlayerCount = %d after rejected join, want 0", n)
+	}
+	if rec := join("hunter2"); rec.Code != http.StatusSeeOther {
+		t.Fatalf("correct password: status %d, want 303", rec.Code)
+	}
+	if n := g.PlayerCount(); n != 1 {
+		t.Errorf("PlayerCount = %d, want 1", n)
+	}
+	if !g.Snapshot(time.Now(), "").IsProtected {
+		t.Error("Snapshot.IsProtected = false for a password game")
+	}
+}
+
+func TestHandler_SetEmojiPool(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	owner := g.AddPlayer("alice")
+	guest := g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	post := func(playerID, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/emojis", strings.NewReader(body))
+		req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	food := `{"emojis":["🍎","🍌","🍇","🍓","🍒","🍑","🍍","🥝","🍅","🥕"]}`
+
+	tests := []struct {
+		name     string
+		playerID string
+		body     string
+		want     int
+	}{
+		{"non-owner", guest.ID, food, http.StatusForbidden},
+		{"bad JSON", owner.ID, `{"emojis":`, http.StatusBadRequest},
+		{"too few", owner.ID, `{"emojis":["🍎","🍌"]}`, http.StatusBadRequest},
+		{"not an emoji", owner.ID, `{"emojis":["🍎","🍌","🍇","🍓","🍒","🍑","🍍","🥝","🍅","carrot"]}`, http.StatusBadRequest},
+		{"valid", owner.ID, food, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		if got := post(tt.playerID, tt.body); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	if len(g.CustomEmojiPool) != 10 {
+		t.Errorf("CustomEmojiPool has %d emojis, want 10", len(g.CustomEmojiPool))
+	}
+
+	if err := g.Start(time.Now()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := post(owner.ID, food); got != http.StatusConflict {
+		t.Errorf("after start: status %d, want 409", got)
+	}
+}
+
+func TestHandler_Guesses(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	guesser := bob
+	if g.ExplainerID == bob.ID {
+		guesser = alice
+	}
+
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/guess", strings.NewReader("guess=zzz"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: guesser.ID})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/guesses", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), guesser.ID) {
+		t.Error("guesses leak a player ID")
+	}
+	var body struct {
+		Guesses []GuessAttempt `json:"guesses"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %q: %v", rec.Body.String(), err)
+	}
+	if len(body.Guesses) != 1 || body.Guesses[0].Guess != "zzz" || body.Guesses[0].PlayerName != guesser.Username || body.Guesses[0].Correct {
+		t.Errorf("guesses = %+v, want one wrong guess zzz by %s", body.Guesses, guesser.Username)
+	}
+}
+
+func TestHandler_SubmitGuess_UnknownPlayerGetsNoBucket(t *testing.T) {
+	store := NewStore()
+	g := store.CreateGame(1, time.Minute, "en", DefaultEmojisPerRound)
+	r := chi.NewRouter()
+	h := NewHandler(store)
+	h.RegisterRoutes(r)
+
+	for i := range 50 {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/guess", strings.NewReader("guess=zzz"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: cookiePrefix + "_" + g.ID, Value: "forged-" + strconv.Itoa(i)})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("forged cookie: status %d, want 303", rec.Code)
+		}
+	}
+	if n := h.guessLimits.Len(); n != 0 {
+		t.Errorf("%d rate-limit buckets allocated for unknown players, want 0", n)
+	}
+}
@@ -0,0 +1,196 @@
+package explain
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"dagame/pkg/realtime"
+)
+
+// ErrGameNotFound is returned by a Repository when it has no game with the ID.
+var ErrGameNotFound = errors.New("game not found")
+
+// Repository saves games so they survive a restart; internal/persistence has
+// a SQL implementation.
+type Repository interface {
+	Save(g *Game) error
+	Load(id string) (*Game, error)
+}
+
+// SetRepository makes the store save a game to repo whenever it publishes an
+// update for it, and look games up in repo when they are not in memory. Call
+// it before serving requests.
+func (s *Store) SetRepository(repo Repository) {
+	s.repo = repo
+}
+
+// loadGame fetches a game missing from memory from the repository, registers
+// it and resumes its round loop.
+func (s *Store) loadGame(id string) (*Game, bool) {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	if room, ok := s.r.Get(id); ok {
+		return room.State, true
+	}
+	g, err := s.repo.Load(id)
+	if err != nil {
+		if !errors.Is(err, ErrGameNotFound) {
+			slog.Error("load game", slog.String("gameID", id), slog.Any("err", err))
+		}
+		return nil, false
+	}
+	s.r.Create(g.ID, g)
+	if g.IsActive() {
+		s.EnsureRoundLoop(g.ID, g)
+	}
+	return g, true
+}
+
+// saveGame writes g to the repository, if any. Failures are logged; the game
+// carries on in memory.
+func (s *Store) saveGame(g *Game) {
+	if s.repo == nil || g == nil {
+		return
+	}
+	if err := s.repo.Save(g); err != nil {
+		slog.Error("save game", slog.String("gameID", g.ID), slog.Any("err", err))
+	}
+}
+
+// savedGame is the stored form of a Game: every field that survives a
+// restart, listed explicitly so new fields are not persisted by accident.
+// The join password is only kept as its hash.
+type savedGame struct {
+	ID               string
+	CreatedAt        time.Time
+	TimedRounds      realtime.TimedRounds
+	RoundData        []RoundData
+	Status           string
+	Lang             string
+	OwnerID          string
+	Players          map[string]*Player
+	JoinOrder        []string
+	ReadyPlayers     map[string]bool
+	EventLog         []GameEvent
+	GuessHistory     GuessLog
+	Word             string
+	ExplainerID      string
+	Canvas           []CanvasItem
+	CanvasHistory    [][]CanvasItem
+	RevealedIndices  []int
+	RoundEmojis      []string
+	EmojisPerRound   int
+	AllowRepeatEmoji bool
+	CustomEmojiPool  []string
+	PasswordHash     []byte `json:",omitempty"`
+	LegacyPassword   string `json:"Password,omitempty"` // plaintext, written before passwords were hashed
+	RoundWinnerID    string
+	RoundSolvedAt    time.Time
+	LatestReaction   ReactEvent
+	RoundDelta       map[string]int
+	RoundEndReason   string
+	HintTokens       int
+	SkipPenalty      int
+	SkipUsed         bool
+}
+
+// EncodeState serialises the game for a Repository. Connection state (who is
+// online, watching streams) is not included.
+func (g *Game) EncodeState() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return json.Marshal(savedGame{
+		ID:               g.ID,
+		CreatedAt:        g.CreatedAt,
+		TimedRounds:      g.TimedRounds,
+		RoundData:        g.RoundData,
+		Status:           g.Status,
+		Lang:             g.Lang,
+		OwnerID:          g.OwnerID,
+		Players:          g.Players,
+		JoinOrder:        g.JoinOrder,
+		ReadyPlayers:     g.ReadyPlayers,
+		EventLog:         g.EventLog,
+		GuessHistory:     g.GuessHistory,
+		Word:             g.Word,
+		ExplainerID:      g.ExplainerID,
+		Canvas:           g.Canvas,
+		CanvasHistory:    g.CanvasHistory,
+		RevealedIndices:  g.RevealedIndices,
+		RoundEmojis:      g.RoundEmojis,
+		EmojisPerRound:   g.EmojisPerRound,
+		AllowRepeatEmoji: g.AllowRepeatEmoji,
+		CustomEmojiPool:  g.CustomEmojiPool,
+		PasswordHash:     g.passwordHash,
+		RoundWinnerID:    g.RoundWinnerID,
+		RoundSolvedAt:    g.RoundSolvedAt,
+		LatestReaction:   g.LatestReaction,
+		RoundDelta:       g.RoundDelta,
+		RoundEndReason:   g.RoundEndReason,
+		HintTokens:       g.HintTokens,
+		SkipPenalty:      g.SkipPenalty,
+		SkipUsed:         g.SkipUsed,
+	})
+}
+
+// DecodeState rebuilds a game saved with EncodeState. Every player starts
+// offline until their stream reconnects.
+func DecodeState(data []byte) (*Game, error) {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	g := &Game{
+		ID:               saved.ID,
+		CreatedAt:        saved.CreatedAt,
+		TimedRounds:      saved.TimedRounds,
+		RoundData:        saved.RoundData,
+		Status:           saved.Status,
+		Lang:             saved.Lang,
+		OwnerID:          saved.OwnerID,
+		Players:          saved.Players,
+		JoinOrder:        saved.JoinOrder,
+		ReadyPlayers:     saved.ReadyPlayers,
+		EventLog:         saved.EventLog,
+		GuessHistory:     saved.GuessHistory,
+		Word:             saved.Word,
+		ExplainerID:      saved.ExplainerID,
+		Canvas:           saved.Canvas,
+		CanvasHistory:    saved.CanvasHistory,
+		RevealedIndices:  saved.RevealedIndices,
+		RoundEmojis:      saved.RoundEmojis,
+		EmojisPerRound:   saved.EmojisPerRound,
+		AllowRepeatEmoji: saved.AllowRepeatEmoji,
+		CustomEmojiPool:  saved.CustomEmojiPool,
+		passwordHash:     saved.PasswordHash,
+		RoundWinnerID:    saved.RoundWinnerID,
+		RoundSolvedAt:    saved.RoundSolvedAt,
+		LatestReaction:   saved.LatestReaction,
+		RoundDelta:       saved.RoundDelta,
+		RoundEndReason:   saved.RoundEndReason,
+		HintTokens:       saved.HintTokens,
+		SkipPenalty:      saved.SkipPenalty,
+		SkipUsed:         saved.SkipUsed,
+	}
+	if saved.LegacyPassword != "" && len(g.passwordHash) == 0 {
+		if err := g.SetPassword(saved.LegacyPassword); err != nil {
+			return nil, err
+		}
+	}
+	if g.Players == nil {
+		g.Players = make(map[string]*Player)
+	}
+	for _, p := range g.Players {
+		p.IsOnline = false
+	}
+	if g.ReadyPlayers == nil {
+		g.ReadyPlayers = make(map[string]bool)
+	}
+	g.OnlineSpectators = make(map[string]bool)
+	if g.RoundDelta == nil {
+		g.RoundDelta = make(map[string]int)
+	}
+	return g, nil
+}
@@ -0,0 +1,73 @@
+package explain
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Event types recorded in Game.EventLog.
+const (
+	EventJoin         = "join"
+	EventStart        = "start"
+	EventGuessRight   = "guess_right"
+	EventGuessWrong   = "guess_wrong"
+	EventRoundAdvance = "round_advance"
+	EventCanvas       = "canvas"
+	EventSkip         = "skip"
+	EventFinish       = "finish"
+)
+
+// ErrReplayHidden is returned when a non-owner asks for the replay of a running game.
+var ErrReplayHidden = errors.New("replay is available to the owner or after the game ends")
+
+// GameEvent is one entry in a game's replay log. Data depends on Type: the
+// username for joins, the guess text for guesses, the new round number for
+// round advances and the JSON-encoded canvas for canvas updates.
+type GameEvent struct {
+	Type     string    `json:"type"`
+	PlayerID string    `json:"player_id,omitempty"`
+	Data     string    `json:"data,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// maxEventLog caps Game.EventLog; older events are dropped first.
+const maxEventLog = 500
+
+// logEventLocked appends to the event log, dropping the oldest entries past
+// maxEventLog. Must be called with g.mu held.
+func (g *Game) logEventLocked(typ, playerID, data string, at time.Time) {
+	g.EventLog = append(g.EventLog, GameEvent{Type: typ, PlayerID: playerID, Data: data, At: at})
+	if over := len(g.EventLog) - maxEventLog; over > 0 {
+		g.EventLog = append([]GameEvent(nil), g.EventLog[over:]...)
+	}
+}
+
+// logCanvasLocked records the current canvas. Consecutive canvas updates
+// collapse into one entry holding the latest canvas, so a replay shows what
+// guessers saw at each guess without storing every drag. Must be called with
+// g.mu held.
+func (g *Game) logCanvasLocked(playerID string, at time.Time) {
+	b, _ := json.Marshal(g.Canvas)
+	if n := len(g.EventLog); n > 0 && g.EventLog[n-1].Type == EventCanvas {
+		g.EventLog[n-1] = GameEvent{Type: EventCanvas, PlayerID: playerID, Data: string(b), At: at}
+		return
+	}
+	g.logEventLocked(EventCanvas, playerID, string(b), at)
+}
+
+func (g *Game) logRoundAdvanceLocked(at time.Time) {
+	g.logEventLocked(EventRoundAdvance, "", strconv.Itoa(g.TimedRounds.CurrentRound), at)
+}
+
+// Replay returns a copy of the event log for the owner, or for anyone once
+// the game has finished.
+func (g *Game) Replay(playerID string) ([]GameEvent, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusFinished && (playerID == "" || playerID != g.OwnerID) {
+		return nil, ErrReplayHidden
+	}
+	return append([]GameEvent(nil), g.EventLog...), nil
+}
@@ -3,11 +3,30 @@
 // templates can use them without creating an import cycle.
 package viewmodel
 
+// LanguageOption is a language choice for the create-game form.
+type LanguageOption struct {
+	Code  string
+	Label string
+}
+
+// GameSummary is an open game listed on the home page.
+type GameSummary struct {
+	ID          string
+	PlayerCount int
+	Status      string
+	Lang        string
+}
+
 // PlayerInfo describes a player as rendered in the UI.
 type PlayerInfo struct {
 	ID          string
 	Name        string
 	IsExplainer bool
+	Solved      bool
+	IsOnline    bool
+	Ready       bool // readied up in the lobby
+	IsSpectator bool
+	Color       string // CSS colour for the name; empty renders it plain
 }
 
 // CanvasItem is an emoji placed on the canvas.
@@ -16,39 +35,74 @@ type CanvasItem struct {
 	Emoji string
 	X     float64
 	Y     float64
+	Size  float64 // font size in rem
 }
 
 // ScoreEntry holds one player's running score.
 type ScoreEntry struct {
 	Name   string
+	Color  string
 	Points int
+	Delta  int // points earned this round
+}
+
+// RoundWinner is a guesser who solved the current round.
+type RoundWinner struct {
+	Name   string
+	Rank   int
+	Points int
+}
+
+// GuessFeedEntry is one recent guess in the live guess feed.
+type GuessFeedEntry struct {
+	Name    string
+	Guess   string
+	Correct bool
 }
 
 // SnapData is a view-friendly representation of the current game snapshot.
 // It is populated by the handler from the domain Snapshot and then passed to
 // templ components.
 type SnapData struct {
-	Status           string
-	CurrentRound     int
-	Rounds           int
-	RoundDurationSec int
-	RoundStartedMs   int64 // Unix milliseconds; drives the client-side countdown
-	NextRoundAtMs    int64 // Unix milliseconds; drives the "next round in" countdown
-	ExplainerName    string
-	RoundWinnerName  string
-	WinnerName       string
-	IsExplainer      bool
-	IsGuesser        bool
-	Word             string // non-empty only for the explainer
-	RevealedWord     string
-	WordLength       int
-	Canvas           []CanvasItem
-	RoundEmojis      []string
-	Players          []PlayerInfo
-	Scores           []ScoreEntry
+	Lang              string // game language, used for <html lang>
+	Status            string
+	CurrentRound      int
+	Rounds            int
+	RoundDurationSec  int
+	RoundStartedMs    int64 // Unix milliseconds; drives the client-side countdown
+	RoundDurationMs   int64
+	NextRoundAtMs     int64 // Unix milliseconds; drives the "next round in" countdown
+	ExplainerName     string
+	NextExplainerName string
+	RoundWinners      []RoundWinner
+	RoundWinnerName   string
+	RoundEndReason    string
+	HintTokens        int
+	SkipUsed          bool // explainer already skipped this round's word
+	IsLastRound       bool
+	WinnerName        string
+	IsExplainer       bool
+	IsGuesser         bool
+	Word              string // non-empty only for the explainer
+	RevealedWord      string
+	WordLength        int
+	Canvas            []CanvasItem
+	RoundEmojis       []string
+	AllowRepeatEmoji  bool // false greys out palette emojis already on the canvas
+	Players           []PlayerInfo
+	SpectatorCount    int // joined as spectators
+	ObserverCount     int // spectators with an open stream
+	Scores            []ScoreEntry
+	ReactionEmoji     string
+	ReactionName      string
+	ReactionAtMs      int64            // Unix milliseconds; the client ignores reactions older than 2s
+	GuessFeed         []GuessFeedEntry // recent guesses, newest last
 
 	// Lobby-only fields computed by the handler.
 	ShowStart   bool
+	IsReady     bool // viewing player has readied up
+	IsSpectator bool // viewing player joined to watch
+	IsOwner     bool // viewing player may extend the round
 	PlayerCount int
 	MinPlayers  int
 
@@ -58,10 +112,11 @@ type SnapData struct {
 
 // GamePageData carries everything the full game page template needs.
 type GamePageData struct {
-	GameID     string
-	InviteURL  string
-	HasPlayer  bool
-	PlayerName string
-	PlayerID   string
-	Snap       SnapData
+	GameID      string
+	InviteURL   string // never carries the room password
+	IsProtected bool   // join form asks for the room password
+	HasPlayer   bool
+	PlayerName  string
+	PlayerID    string
+	Snap        SnapData
 }
@@ -5,6 +5,9 @@ import (
 	"io/fs"
 	"math/rand"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
 //go:embed words/*.txt
@@ -12,20 +15,54 @@ var wordsFS embed.FS
 
 const minWordLen = 5
 
+// wordList is a parsed word file, or the error reading it.
+type wordList struct {
+	words []string
+	err   error
+}
+
+// wordLists caches parsed word files by language code.
+var wordLists sync.Map // string -> wordList
+
+// wordListOnce guards the first parse of each supported language; it is only
+// read after package init, and unsupported codes bypass the cache.
+var wordListOnce = func() map[string]*sync.Once {
+	m := make(map[string]*sync.Once)
+	for _, lang := range SupportedLanguages() {
+		m[lang] = new(sync.Once)
+	}
+	return m
+}()
+
+// loadWords returns the words in the embedded file for lang, parsed once per
+// language. The slice is shared; callers must not modify it.
 func loadWords(lang string) ([]string, error) {
 	name := strings.TrimSpace(lang)
 	if name == "" {
 		name = "en"
 	}
-	name = "words/" + name + ".txt"
-	b, err := fs.ReadFile(wordsFS, name)
+	once, ok := wordListOnce[name]
+	if !ok {
+		return readWords(name)
+	}
+	once.Do(func() {
+		words, err := readWords(name)
+		wordLists.Store(name, wordList{words: words, err: err})
+	})
+	cached, _ := wordLists.Load(name)
+	list := cached.(wordList)
+	return list.words, list.err
+}
+
+func readWords(lang string) ([]string, error) {
+	b, err := fs.ReadFile(wordsFS, "words/"+lang+".txt")
 	if err != nil {
 		return nil, err
 	}
 	var out []string
 	for _, line := range strings.Split(string(b), "\n") {
 		w := strings.TrimSpace(strings.ToLower(line))
-		if len(w) >= minWordLen {
+		if utf8.RuneCountInString(w) >= minWordLen {
 			out = append(out, w)
 		}
 	}
@@ -46,5 +83,32 @@ func PickRandomWord(lang string, rng *rand.Rand) string {
 
 // SupportedLanguages returns language codes that have an embedded word list.
 func SupportedLanguages() []string {
-	return []string{"en"}
+	return []string{"en", "es", "no"}
+}
+
+// accentFolds maps accented Latin letters to their base letter for guess matching.
+var accentFolds = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+// foldAccents strips diacritics so "cancion" matches "canción" whether the
+// accent was typed precomposed or as a combining mark.
+func foldAccents(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if base, ok := accentFolds[r]; ok {
+			r = base
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
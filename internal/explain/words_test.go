@@ -0,0 +1,99 @@
+package explain
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestPickRandomWord_Spanish(t *testing.T) {
+	words, err := loadWords("es")
+	if err != nil {
+		t.Fatalf("loadWords(es): %v", err)
+	}
+	if len(words) < 300 {
+		t.Fatalf("Spanish list has %d words, want at least 300", len(words))
+	}
+	known := make(map[string]bool, len(words))
+	for _, w := range words {
+		known[w] = true
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		w := PickRandomWord("es", rng)
+		if w == "" || !known[w] {
+			t.Fatalf("PickRandomWord(es) = %q, want a word from the Spanish list", w)
+		}
+		if utf8.RuneCountInString(w) < minWordLen {
+			t.Errorf("%q is shorter than %d letters", w, minWordLen)
+		}
+	}
+}
+
+func TestPickRandomWord_Norwegian(t *testing.T) {
+	words, err := loadWords("no")
+	if err != nil {
+		t.Fatalf("loadWords(no): %v", err)
+	}
+	known := make(map[string]bool, len(words))
+	for _, w := range words {
+		known[w] = true
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if w := PickRandomWord("no", rng); w == "" || !known[w] {
+			t.Fatalf("PickRandomWord(no) = %q, want a word from the Norwegian list", w)
+		}
+	}
+}
+
+func TestNewGame_Norwegian(t *testing.T) {
+	g := NewGame(2, time.Minute, "no", DefaultEmojisPerRound)
+	words, _ := loadWords("no")
+	known := make(map[string]bool, len(words))
+	for _, w := range words {
+		known[w] = true
+	}
+	for i, rd := range g.RoundData {
+		if !known[rd.Word] {
+			t.Errorf("round %d word %q is not in the Norwegian list", i, rd.Word)
+		}
+	}
+	if snap := g.Snapshot(time.Now(), ""); snap.Lang != "no" {
+		t.Errorf("Snapshot.Lang = %q, want no", snap.Lang)
+	}
+}
+
+func TestFoldAccents(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"canción", "cancion"},
+		{"canción", "cancion"}, // combining acute accent
+		{"pingüino", "pinguino"},
+		{"plain", "plain"},
+	} {
+		if got := foldAccents(tc.in); got != tc.want {
+			t.Errorf("foldAccents(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestLoadWords_Cached(t *testing.T) {
+	var wg sync.WaitGroup
+	lists := make([][]string, 2)
+	for i := range lists {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lists[i], _ = loadWords("es")
+		}()
+	}
+	wg.Wait()
+	if len(lists[0]) == 0 || len(lists[1]) == 0 {
+		t.Fatal("loadWords(es) returned no words")
+	}
+	if &lists[0][0] != &lists[1][0] {
+		t.Error("concurrent loadWords calls parsed the list twice")
+	}
+}
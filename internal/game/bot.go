@@ -0,0 +1,108 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// botPollInterval is how often a bot checks for a new round to play.
+const botPollInterval = 250 * time.Millisecond
+
+// Bot is a computer player for single-player practice. Skill ranges from 0
+// to 1: a skill of 1 guesses immediately, lower skills wait longer.
+type Bot struct {
+	Name  string
+	Skill float64
+
+	playerID string
+}
+
+// guessDelay picks how long after the round starts the bot answers:
+// (1 - Skill) * duration, scaled by a random factor in [0.5, 1.5).
+func (b *Bot) guessDelay(duration time.Duration) time.Duration {
+	return time.Duration((1 - b.Skill) * float64(duration) * (0.5 + rand.Float64()))
+}
+
+// Run plays every round of g until ctx is cancelled or the game finishes.
+// Correct guesses are published like a human's.
+func (b *Bot) Run(ctx context.Context, g *Game, s *Store) {
+	lastRound := 0
+	for {
+		snap := g.Snapshot(time.Now().UTC())
+		if snap.Status == StatusFinished {
+			return
+		}
+		if snap.Status != StatusInProgress || !snap.RoundEndedAt.IsZero() || snap.CurrentRound == lastRound {
+			if !sleepCtx(ctx, botPollInterval) {
+				return
+			}
+			continue
+		}
+		lastRound = snap.CurrentRound
+		guessAt := snap.RoundStarted.Add(b.guessDelay(snap.RoundDuration))
+		if !sleepCtx(ctx, time.Until(guessAt)) {
+			return
+		}
+		now := time.Now().UTC()
+		cur := g.Snapshot(now)
+		if cur.CurrentRound != lastRound || !cur.RoundEndedAt.IsZero() {
+			continue // someone else solved it, or the round timed out
+		}
+		ok, _ := g.SubmitGuess(b.playerID, cur.RoundData.Word, now)
+		if ok {
+			s.WakeRoundLoop(g.ID)
+			s.Publish(g.ID, "round")
+			s.Publish(g.ID, "scores")
+			s.Publish(g.ID, "players")
+		}
+	}
+}
+
+// addBotPlayer joins a player flagged as a bot.
+func (g *Game) addBotPlayer(name string) *Player {
+	player := g.AddPlayer(name)
+	g.mu.Lock()
+	player.IsBot = true
+	g.mu.Unlock()
+	return player
+}
+
+// sleepCtx waits for d or until ctx is done, reporting whether the full wait elapsed.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// AddBot joins a bot player to a game and starts it playing. The bot readies
+// up in the lobby so it never holds back an auto-start, and it stops once the
+// game finishes or is deleted from s. A human must join first so the bot never owns the game.
+func (s *Store) AddBot(gameID, botName string, skill float64) (*Bot, error) {
+	g, ok := s.GetGame(gameID)
+	if !ok {
+		return nil, errors.New("game not found")
+	}
+	if g.PlayerCount() == 0 {
+		return nil, errors.New("a player must join before adding a bot")
+	}
+	bot := &Bot{Name: botName, Skill: min(max(skill, 0), 1)}
+	bot.playerID = g.addBotPlayer(botName).ID
+	if err := g.ReadyUp(bot.playerID, time.Now().UTC()); errors.Is(err, ErrAutoStarted) {
+		s.EnsureRoundLoop(gameID, g)
+		s.Publish(gameID, "round")
+	}
+	s.Publish(gameID, "players")
+	s.Publish(gameID, "scores")
+	go bot.Run(s.r.Context(gameID), g, s)
+	return bot, nil
+}
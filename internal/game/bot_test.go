@@ -0,0 +1,95 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStore_AddBot_Guesses(t *testing.T) {
+	s := NewStore()
+	g := s.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
+
+	if _, err := s.AddBot(g.ID, "robo", 1); err == nil {
+		t.Fatal("AddBot into an empty game should fail")
+	}
+	g.AddPlayer("alice")
+	bot, err := s.AddBot(g.ID, "robo", 1)
+	if err != nil {
+		t.Fatalf("AddBot: %v", err)
+	}
+	if !g.IsReady(bot.playerID) {
+		t.Error("bot should ready up in the lobby")
+	}
+	if err := g.Start(time.Now().UTC()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, entry := range g.Scores() {
+			if entry.Name == "robo" && entry.Points > 0 {
+				return
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("skill-1 bot did not score")
+}
+
+func TestBot_GuessDelay(t *testing.T) {
+	b := &Bot{Skill: 0.5}
+	for i := 0; i < 100; i++ {
+		d := b.guessDelay(time.Minute)
+		if d < 15*time.Second || d >= 45*time.Second {
+			t.Fatalf("guessDelay = %v, want within [15s, 45s)", d)
+		}
+	}
+	if d := (&Bot{Skill: 1}).guessDelay(time.Minute); d != 0 {
+		t.Errorf("skill 1 delay = %v, want 0", d)
+	}
+}
+
+func TestStore_AddBot_StopsOnDelete(t *testing.T) {
+	s := NewStore()
+	g := s.CreateGame(1, time.Minute, "en")
+	g.AddPlayer("alice")
+	if _, err := s.AddBot(g.ID, "robo", 0.5); err != nil {
+		t.Fatalf("AddBot: %v", err)
+	}
+	ctx := s.r.Context(g.ID)
+	s.DeleteGame(g.ID)
+	if ctx.Err() == nil {
+		t.Error("bot context should be cancelled once the game is deleted")
+	}
+}
+
+func TestStore_AddBot_SurvivesInactivityPruning(t *testing.T) {
+	s := NewStore()
+	g := s.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	bot, err := s.AddBot(g.ID, "robo", 0)
+	if err != nil {
+		t.Fatalf("AddBot: %v", err)
+	}
+	now := time.Now().UTC()
+	_ = g.Start(now)
+
+	later := now.Add(InactivityThreshold + time.Minute)
+	g.Touch(alice.ID, later)
+	if pruned := g.PruneInactivePlayers(InactivityThreshold, later); len(pruned) != 0 {
+		t.Errorf("pruned %q; bots make no requests and must stay", pruned)
+	}
+
+	muchLater := later.Add(InactivityThreshold + time.Minute)
+	if pruned := g.PruneInactivePlayers(InactivityThreshold, muchLater); len(pruned) != 1 || pruned[0] != alice.ID {
+		t.Errorf("pruned %q, want only alice", pruned)
+	}
+	if _, ok := g.PlayerName(bot.playerID); !ok {
+		t.Error("bot removed with the humans")
+	}
+	if g.IsOwner(bot.playerID) {
+		t.Error("ownership passed to the bot")
+	}
+}
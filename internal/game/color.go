@@ -0,0 +1,19 @@
+package game
+
+import "hash/fnv"
+
+// playerPalette holds the name colours handed out to players. They are dark
+// enough to read on the light card background.
+var playerPalette = [12]string{
+	"#d32f2f", "#c2185b", "#7b1fa2", "#512da8",
+	"#1976d2", "#0097a7", "#00796b", "#388e3c",
+	"#827717", "#ef6c00", "#5d4037", "#455a64",
+}
+
+// PlayerColor picks a palette colour from the player ID, so a player keeps
+// the same colour for the whole game.
+func PlayerColor(playerID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(playerID))
+	return playerPalette[h.Sum32()%uint32(len(playerPalette))]
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+var hexColor = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+func TestPlayerColor(t *testing.T) {
+	a, b := PlayerColor("player-a"), PlayerColor("player-b")
+	for _, c := range []string{a, b} {
+		if !hexColor.MatchString(c) || !slices.Contains(playerPalette[:], c) {
+			t.Errorf("PlayerColor = %q, want a hex colour from the palette", c)
+		}
+	}
+	if a == b {
+		t.Errorf("player-a and player-b both got %q", a)
+	}
+	if again := PlayerColor("player-a"); again != a {
+		t.Errorf("PlayerColor not deterministic: %q then %q", a, again)
+	}
+}
+
+func TestGame_AddPlayer_AssignsColor(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	g.AddSpectator("sam")
+	if alice.Color != PlayerColor(alice.ID) {
+		t.Errorf("Color = %q, want %q", alice.Color, PlayerColor(alice.ID))
+	}
+
+	snap := g.Snapshot(time.Now().UTC())
+	for _, info := range append(snap.Players, snap.Spectators...) {
+		if !hexColor.MatchString(info.Color) {
+			t.Errorf("PlayerInfo %q has colour %q", info.Name, info.Color)
+		}
+	}
+	if len(snap.Scores) != 1 || snap.Scores[0].Color != alice.Color {
+		t.Errorf("Scores = %+v, want alice's colour %q", snap.Scores, alice.Color)
+	}
+	if len(snap.Progress) != 1 || snap.Progress[0].Color != alice.Color {
+		t.Errorf("Progress = %+v, want alice's colour %q", snap.Progress, alice.Color)
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+// MaxFuzzyTolerance is the largest edit distance SetFuzzyTolerance accepts.
+const MaxFuzzyTolerance = 2
+
+// levenshtein returns the number of single-rune insertions, deletions and
+// substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// SetFuzzyTolerance lets SubmitGuess accept guesses within n edits of the word
+// for half points. n is clamped to 0 (exact matches only) through MaxFuzzyTolerance.
+func (g *Game) SetFuzzyTolerance(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.FuzzyTolerance = min(max(n, 0), MaxFuzzyTolerance)
+}
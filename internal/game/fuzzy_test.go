@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"tiger", "tiger", 0},
+		{"tiger", "tigre", 2},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"planet", "plant", 1},
+		{"planet", "planets", 1},
+		{"gjøre", "gjore", 1}, // counts runes, not bytes
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGame_SetFuzzyTolerance_Clamps(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	for _, tt := range []struct{ in, want int }{{-1, 0}, {0, 0}, {1, 1}, {2, 2}, {5, MaxFuzzyTolerance}} {
+		g.SetFuzzyTolerance(tt.in)
+		if g.FuzzyTolerance != tt.want {
+			t.Errorf("SetFuzzyTolerance(%d) = %d, want %d", tt.in, g.FuzzyTolerance, tt.want)
+		}
+	}
+}
+
+// typo replaces the first n letters of word with letters that differ.
+func typo(word string, n int) string {
+	r := []rune(word)
+	for i := 0; i < n && i < len(r); i++ {
+		if r[i] == 'q' {
+			r[i] = 'z'
+		} else {
+			r[i] = 'q'
+		}
+	}
+	return string(r)
+}
+
+func TestSubmitGuess_Fuzzy(t *testing.T) {
+	tests := []struct {
+		name       string
+		tolerance  int
+		typos      int
+		wantOK     bool
+		wantPoints int
+	}{
+		{"exact only rejects a typo", 0, 1, false, 0},
+		{"one typo within tolerance scores half", 1, 1, true, 2},
+		{"two typos beyond tolerance", 1, 2, false, 0},
+		{"two typos within tolerance", 2, 2, true, 2},
+		{"exact guess keeps full points", 2, 0, true, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			g := NewGame(1, time.Minute, "en")
+			p := g.AddPlayer("alice")
+			g.SetPointsFormula(func(_, _ time.Duration) int { return 4 })
+			g.SetFuzzyTolerance(tt.tolerance)
+			_ = g.Start(now)
+
+			ok, err := g.SubmitGuess(p.ID, typo(g.CurrentRoundData().Word, tt.typos), now)
+			if err != nil {
+				t.Fatalf("SubmitGuess: %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("SubmitGuess ok = %v, want %v", ok, tt.wantOK)
+			}
+			if p.Points != tt.wantPoints {
+				t.Errorf("Points = %d, want %d", p.Points, tt.wantPoints)
+			}
+			if ok && g.RoundWinnerIDs[0] != p.ID {
+				t.Errorf("RoundWinnerIDs = %q, want [%q]", g.RoundWinnerIDs, p.ID)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package game
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"dagame/pkg/realtime"
 )
 
 func TestNewGame(t *testing.T) {
@@ -53,9 +58,15 @@ func TestGame_AddPlayer(t *testing.T) {
 	if len(g.Players) != 2 {
 		t.Errorf("len(Players) %d, want 2", len(g.Players))
 	}
+	if g.PlayerCount() != 2 {
+		t.Errorf("PlayerCount %d, want 2", g.PlayerCount())
+	}
 	if g.OwnerID != p1.ID {
 		t.Errorf("OwnerID should stay first player, got %q", g.OwnerID)
 	}
+	if len(g.JoinOrder) != 2 || g.JoinOrder[0] != p1.ID || g.JoinOrder[1] != p2.ID {
+		t.Errorf("JoinOrder %v, want [%s %s]", g.JoinOrder, p1.ID, p2.ID)
+	}
 }
 
 func TestGame_Start(t *testing.T) {
@@ -86,6 +97,83 @@ func TestGame_Start(t *testing.T) {
 	}
 }
 
+func TestGame_ReadyUp_AutoStarts(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	p1 := g.AddPlayer("alice")
+	p2 := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	if err := g.ReadyUp(p1.ID, now); err != nil {
+		t.Fatalf("ReadyUp(alice): %v", err)
+	}
+	if g.Status != StatusLobby {
+		t.Fatalf("Status %q after one ready, want %q", g.Status, StatusLobby)
+	}
+	if err := g.ReadyUp(p2.ID, now); !errors.Is(err, ErrAutoStarted) {
+		t.Fatalf("ReadyUp(bob) = %v, want ErrAutoStarted", err)
+	}
+	if g.Status != StatusInProgress {
+		t.Errorf("Status %q, want %q", g.Status, StatusInProgress)
+	}
+	if err := g.ReadyUp(p1.ID, now); err == nil {
+		t.Error("ReadyUp after start should fail")
+	}
+}
+
+func TestGame_ReadyUp_NeedsMinPlayers(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	if err := g.ReadyUp(alice.ID, time.Now().UTC()); err != nil {
+		t.Fatalf("ReadyUp(alice): %v", err)
+	}
+	if g.Status != StatusLobby {
+		t.Errorf("a lone ready player started the game")
+	}
+}
+
+func TestGame_AddRound(t *testing.T) {
+	g := NewGame(MaxRounds-1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	guest := g.AddPlayer("bob")
+	if err := g.AddRound(guest.ID); err == nil {
+		t.Error("AddRound by non-owner should fail")
+	}
+	if err := g.AddRound(owner.ID); err != nil {
+		t.Fatalf("AddRound: %v", err)
+	}
+	if len(g.RoundData) != MaxRounds || g.TimedRounds.Rounds != MaxRounds {
+		t.Errorf("rounds %d/%d, want %d", len(g.RoundData), g.TimedRounds.Rounds, MaxRounds)
+	}
+	if err := g.AddRound(owner.ID); err == nil {
+		t.Error("AddRound past MaxRounds should fail")
+	}
+	if err := g.RemoveLastRound(owner.ID); err != nil {
+		t.Fatalf("RemoveLastRound: %v", err)
+	}
+	if g.TimedRounds.Rounds != MaxRounds-1 {
+		t.Errorf("Rounds %d, want %d", g.TimedRounds.Rounds, MaxRounds-1)
+	}
+}
+
+func TestGame_BanWord(t *testing.T) {
+	g := NewGame(3, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	banned := g.RoundData[1].Word
+	if err := g.BanWord(owner.ID, strings.ToUpper(banned)); err != nil {
+		t.Fatalf("BanWord: %v", err)
+	}
+	for i, round := range g.RoundData {
+		if round.Word == banned {
+			t.Errorf("round %d still uses banned word %q", i+1, banned)
+		}
+	}
+	g.Restart(time.Now().UTC())
+	for i, round := range g.RoundData {
+		if round.Word == banned {
+			t.Errorf("after Restart round %d uses banned word %q", i+1, banned)
+		}
+	}
+}
+
 func TestGame_SubmitGuess(t *testing.T) {
 	now := time.Now().UTC()
 	g := NewGame(1, time.Minute, "en")
@@ -107,8 +195,8 @@ func TestGame_SubmitGuess(t *testing.T) {
 	if p.Points < 1 {
 		t.Errorf("player should have points, got %d", p.Points)
 	}
-	if g.RoundWinnerID != p.ID {
-		t.Errorf("RoundWinnerID %q, want %q", g.RoundWinnerID, p.ID)
+	if len(g.RoundWinnerIDs) != 1 || g.RoundWinnerIDs[0] != p.ID {
+		t.Errorf("RoundWinnerIDs %q, want [%q]", g.RoundWinnerIDs, p.ID)
 	}
 
 	// Wrong guess (same round already won)
@@ -225,7 +313,13 @@ func TestGame_Snapshot(t *testing.T) {
 		t.Errorf("Snapshot Status %q, want lobby", snap.Status)
 	}
 	if len(snap.Players) != 2 {
-		t.Errorf("Snapshot Players len %d, want 2", len(snap.Players))
+		t.Fatalf("Snapshot Players len %d, want 2", len(snap.Players))
+	}
+	if snap.Players[0].Name != "alice" || snap.Players[1].Name != "bob" {
+		t.Errorf("Snapshot Players %v, want alice then bob", snap.Players)
+	}
+	if snap.Players[1].JoinedAt.Before(snap.Players[0].JoinedAt) {
+		t.Error("Snapshot Players not sorted by join time")
 	}
 	if len(snap.Scores) != 2 {
 		t.Errorf("Snapshot Scores len %d, want 2", len(snap.Scores))
@@ -265,3 +359,508 @@ func TestGame_PlayerName(t *testing.T) {
 		t.Error("PlayerName should return false for unknown ID")
 	}
 }
+
+func TestGame_PlayerNames_JoinOrder(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	for _, name := range []string{"carol", "alice", "bob", "dave"} {
+		g.AddPlayer(name)
+	}
+	want := []string{"carol", "alice", "bob", "dave"}
+	for i := 0; i < 5; i++ {
+		got := g.PlayerNames()
+		if len(got) != len(want) {
+			t.Fatalf("PlayerNames %v, want %v", got, want)
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Fatalf("PlayerNames %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestGame_RequestHint_DeductsPoint(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	p := g.AddPlayer("alice")
+	_ = g.Start(now)
+	word := []rune(g.CurrentRoundData().Word)
+	if len(word) == 0 {
+		t.Fatal("no round word (empty word list?)")
+	}
+	p.Points = 3
+
+	revealed, err := g.RequestHint(p.ID, now)
+	if err != nil {
+		t.Fatalf("RequestHint: %v", err)
+	}
+	want := string(word[0]) + strings.Repeat("_", len(word)-1)
+	if revealed != want {
+		t.Errorf("revealed %q, want %q", revealed, want)
+	}
+	if p.Points != 2 {
+		t.Errorf("Points %d, want 2", p.Points)
+	}
+
+	p.Points = 0
+	if _, err := g.RequestHint(p.ID, now); err != nil {
+		t.Fatalf("second RequestHint: %v", err)
+	}
+	if p.Points != 0 {
+		t.Errorf("Points %d, want floor of 0", p.Points)
+	}
+	if len(p.HintedIndices) != 2 || p.HintedIndices[1] != 1 {
+		t.Errorf("HintedIndices %v, want [0 1]", p.HintedIndices)
+	}
+}
+
+func TestGame_Snapshot_RevealedWordAfterTimeout(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	_ = g.Start(start)
+	word := g.CurrentRoundData().Word
+
+	if snap := g.Snapshot(start.Add(time.Second)); snap.RevealedWord != "" {
+		t.Errorf("RevealedWord %q during active round, want empty", snap.RevealedWord)
+	}
+	g.UpdateProgress(alice.ID, 2, start.Add(2*time.Second))
+	snap := g.Snapshot(start.Add(time.Minute + time.Second))
+	if snap.RevealedWord != word {
+		t.Errorf("RevealedWord %q after timeout, want %q", snap.RevealedWord, word)
+	}
+	if alice.Progress != 0 {
+		t.Errorf("progress after timeout = %d, want 0", alice.Progress)
+	}
+	snap = g.Snapshot(start.Add(time.Minute + realtime.DefaultCooldown + 2*time.Second))
+	if snap.CurrentRound != 2 || snap.RevealedWord != "" {
+		t.Errorf("round %d RevealedWord %q, want round 2 with empty RevealedWord", snap.CurrentRound, snap.RevealedWord)
+	}
+}
+
+func TestGame_EstimatedTimeRemaining(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(3, time.Minute, "en")
+	g.AddPlayer("alice")
+
+	want := 3*time.Minute + 2*realtime.DefaultCooldown
+	if got := g.EstimatedTimeRemaining(); got != want {
+		t.Errorf("lobby estimate = %v, want %v", got, want)
+	}
+	_ = g.Start(now)
+	snap := g.Snapshot(now.Add(10 * time.Second))
+	wantSec := int((50*time.Second + 2*(time.Minute+realtime.DefaultCooldown)).Seconds())
+	if snap.EstimatedRemainingSec != wantSec {
+		t.Errorf("EstimatedRemainingSec = %d, want %d", snap.EstimatedRemainingSec, wantSec)
+	}
+}
+
+func TestGame_StatusHelpers(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	g.AddPlayer("alice")
+	if !g.IsLobby() || g.IsActive() || g.IsFinished() {
+		t.Error("new game should only be in the lobby")
+	}
+	_ = g.Start(now)
+	if g.IsLobby() || !g.IsActive() || g.IsFinished() {
+		t.Error("started game should only be active")
+	}
+	g.AdvanceIfNeeded(now.Add(2 * time.Minute))
+	g.AdvanceIfNeeded(now.Add(3 * time.Minute))
+	if g.IsLobby() || g.IsActive() || !g.IsFinished() {
+		t.Errorf("game past its last round should only be finished, status %q", g.Status)
+	}
+}
+
+func TestGame_Snapshot_PlayersInJoinOrder(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	zoe := g.AddPlayer("zoe")
+	g.AddPlayer("adam")
+	zoe.Progress = 3
+
+	snap := g.Snapshot(time.Now().UTC())
+	if len(snap.PlayersInJoinOrder) != 2 || snap.PlayersInJoinOrder[0].Name != "zoe" || snap.PlayersInJoinOrder[1].Name != "adam" {
+		t.Fatalf("PlayersInJoinOrder = %+v, want zoe then adam", snap.PlayersInJoinOrder)
+	}
+	if !snap.PlayersInJoinOrder[0].JoinedAt.Equal(zoe.JoinedAt) {
+		t.Errorf("JoinedAt = %v, want %v", snap.PlayersInJoinOrder[0].JoinedAt, zoe.JoinedAt)
+	}
+	if snap.Progress[0].Name != "zoe" || snap.Progress[0].JoinedAt.IsZero() {
+		t.Errorf("Progress should stay sorted by correct letters and carry JoinedAt: %+v", snap.Progress)
+	}
+}
+
+func TestGame_MutePlayer(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	_ = g.Start(now)
+
+	if err := g.MutePlayer(bob.ID, owner.ID); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("non-owner MutePlayer err = %v, want ErrNotOwner", err)
+	}
+	if err := g.MutePlayer(owner.ID, bob.ID); err != nil {
+		t.Fatalf("MutePlayer: %v", err)
+	}
+	if !g.IsMuted(bob.ID) {
+		t.Error("bob should be muted")
+	}
+	if snap := g.Snapshot(now); !snap.Players[1].Muted {
+		t.Error("Snapshot.Players should report bob as muted")
+	}
+	word := g.CurrentRoundData().Word
+	if ok, err := g.SubmitGuess(bob.ID, word, now); ok || !errors.Is(err, ErrPlayerMuted) {
+		t.Errorf("muted SubmitGuess = %t, %v; want false, ErrPlayerMuted", ok, err)
+	}
+
+	if err := g.UnmutePlayer(owner.ID, bob.ID); err != nil {
+		t.Fatalf("UnmutePlayer: %v", err)
+	}
+	if ok, err := g.SubmitGuess(bob.ID, word, now); !ok || err != nil {
+		t.Errorf("unmuted SubmitGuess = %t, %v; want true, nil", ok, err)
+	}
+}
+
+func TestGame_ExtendRound(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	if err := g.Start(start); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	now := start.Add(59 * time.Second) // one second left
+	if next, _ := g.NextTimer(now); !next.Equal(start.Add(time.Minute)) {
+		t.Fatalf("NextTimer before extend = %v, want %v", next, start.Add(time.Minute))
+	}
+
+	if err := g.ExtendRound(bob.ID, 10*time.Second, now); err == nil {
+		t.Error("non-owner ExtendRound should fail")
+	}
+	if err := g.ExtendRound(owner.ID, 10*time.Second, now); err != nil {
+		t.Fatalf("ExtendRound: %v", err)
+	}
+	want := start.Add(time.Minute + 10*time.Second)
+	if next, ok := g.NextTimer(now); !ok || !next.Equal(want) {
+		t.Errorf("NextTimer after extend = %v, %t; want %v", next, ok, want)
+	}
+	if g.AdvanceIfNeeded(start.Add(time.Minute + time.Second)) {
+		t.Error("round ended at the original deadline despite the extension")
+	}
+	if err := g.ExtendRound(owner.ID, 10*time.Second, want.Add(time.Second)); err == nil {
+		t.Error("ExtendRound after the deadline should fail")
+	}
+}
+
+func TestGame_KickPlayer(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	carol := g.AddPlayer("carol")
+	_ = g.Start(now)
+	if ok, _ := g.SubmitGuess(bob.ID, g.CurrentRoundData().Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+
+	if err := g.KickPlayer(bob.ID, carol.ID); err == nil {
+		t.Error("non-owner KickPlayer should fail")
+	}
+	if err := g.KickPlayer(owner.ID, "missing"); err == nil {
+		t.Error("KickPlayer of unknown player should fail")
+	}
+	if err := g.KickPlayer(owner.ID, bob.ID); err != nil {
+		t.Fatalf("KickPlayer: %v", err)
+	}
+	if _, ok := g.PlayerName(bob.ID); ok {
+		t.Error("bob still in the game after kick")
+	}
+	if len(g.RoundWinnerIDs) != 0 {
+		t.Errorf("RoundWinnerIDs = %q, want cleared after kicking the winner", g.RoundWinnerIDs)
+	}
+	if g.PlayerCount() != 2 {
+		t.Errorf("PlayerCount = %d, want 2", g.PlayerCount())
+	}
+}
+
+func TestGame_KickPlayer_OwnerSelfKickTransfersOwnership(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	carol := g.AddPlayer("carol")
+	g.AddSpectator("dave").JoinedAt = owner.JoinedAt.Add(-time.Hour)
+	// carol joined after bob but claims an earlier JoinedAt; JoinedAt wins.
+	bob.JoinedAt = owner.JoinedAt.Add(2 * time.Second)
+	carol.JoinedAt = owner.JoinedAt.Add(time.Second)
+
+	if err := g.KickPlayer(owner.ID, owner.ID); err != nil {
+		t.Fatalf("self KickPlayer: %v", err)
+	}
+	if !g.IsOwner(carol.ID) {
+		t.Errorf("owner = %q, want earliest-joined player carol (spectators never own)", g.OwnerID)
+	}
+	if err := g.KickPlayer(carol.ID, bob.ID); err != nil {
+		t.Fatalf("new owner KickPlayer: %v", err)
+	}
+	if err := g.KickPlayer(carol.ID, carol.ID); err != nil {
+		t.Fatalf("last player self KickPlayer: %v", err)
+	}
+	if g.OwnerID != "" {
+		t.Errorf("OwnerID = %q, want empty with no players left", g.OwnerID)
+	}
+}
+
+func TestGame_PruneInactivePlayers(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	sam := g.AddSpectator("sam")
+	now := owner.JoinedAt.Add(10 * time.Minute)
+	_ = g.Start(now)
+
+	g.Touch(owner.ID, now.Add(-30*time.Second))
+	g.Touch(sam.ID, now.Add(-time.Minute))
+	g.Touch(bob.ID, now.Add(-3*time.Minute))
+	g.Touch("missing", now) // unknown players are ignored
+
+	pruned := g.PruneInactivePlayers(2*time.Minute, now)
+	if len(pruned) != 1 || pruned[0] != bob.ID {
+		t.Fatalf("pruned = %q, want only bob", pruned)
+	}
+	if _, ok := g.PlayerName(bob.ID); ok {
+		t.Error("bob still in the game after pruning")
+	}
+	if g.PlayerCount() != 1 {
+		t.Errorf("PlayerCount = %d, want 1", g.PlayerCount())
+	}
+	if !g.IsOwner(owner.ID) {
+		t.Errorf("OwnerID = %q, want alice to stay owner", g.OwnerID)
+	}
+	if again := g.PruneInactivePlayers(2*time.Minute, now); len(again) != 0 {
+		t.Errorf("second prune = %q, want nothing", again)
+	}
+}
+
+func TestGame_PruneInactivePlayers_TransfersOwnership(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	carol := g.AddPlayer("carol")
+	bob.JoinedAt = owner.JoinedAt.Add(2 * time.Second)
+	carol.JoinedAt = owner.JoinedAt.Add(time.Second)
+	now := owner.JoinedAt.Add(10 * time.Minute)
+	g.Touch(bob.ID, now)
+	g.Touch(carol.ID, now)
+
+	if pruned := g.PruneInactivePlayers(2*time.Minute, now); len(pruned) != 1 || pruned[0] != owner.ID {
+		t.Fatalf("pruned = %q, want only the owner", pruned)
+	}
+	if !g.IsOwner(carol.ID) {
+		t.Errorf("OwnerID = %q, want earliest-joined player carol", g.OwnerID)
+	}
+
+	later := now.Add(5 * time.Minute)
+	if pruned := g.PruneInactivePlayers(2*time.Minute, later); len(pruned) != 2 {
+		t.Fatalf("pruned = %q, want bob and carol", pruned)
+	}
+	if g.OwnerID != "" {
+		t.Errorf("OwnerID = %q, want empty with no players left", g.OwnerID)
+	}
+}
+
+func TestGame_TransferOwnership(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	sam := g.AddSpectator("sam")
+
+	tests := []struct {
+		name      string
+		callerID  string
+		newID     string
+		wantErr   error // nil means any error; see wantOK
+		wantOK    bool
+		wantOwner string
+	}{
+		{"non-owner", bob.ID, bob.ID, ErrNotOwner, false, owner.ID},
+		{"no caller", "", bob.ID, ErrNotOwner, false, owner.ID},
+		{"unknown player", owner.ID, "missing", nil, false, owner.ID},
+		{"spectator", owner.ID, sam.ID, ErrSpectator, false, owner.ID},
+		{"owner to bob", owner.ID, bob.ID, nil, true, bob.ID},
+		{"old owner locked out", owner.ID, owner.ID, ErrNotOwner, false, bob.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := g.TransferOwnership(tt.callerID, tt.newID)
+			switch {
+			case tt.wantOK && err != nil:
+				t.Fatalf("TransferOwnership: %v", err)
+			case !tt.wantOK && err == nil:
+				t.Fatal("TransferOwnership succeeded, want an error")
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !g.IsOwner(tt.wantOwner) {
+				t.Errorf("OwnerID = %q, want %q", g.OwnerID, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestGame_RestartWithOptions_KeepsWords(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(3, time.Minute, "en")
+	p := g.AddPlayer("alice")
+	_ = g.Start(now)
+	if ok, _ := g.SubmitGuess(p.ID, g.CurrentRoundData().Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+	words := append([]Round(nil), g.RoundData...)
+
+	g.RestartWithOptions(now, RestartOptions{RebuildWords: false})
+	for i := range words {
+		if g.RoundData[i] != words[i] {
+			t.Errorf("round %d = %+v, want %+v", i+1, g.RoundData[i], words[i])
+		}
+	}
+	if p.Points != 0 || p.Progress != 0 {
+		t.Errorf("player points/progress = %d/%d after restart, want 0/0", p.Points, p.Progress)
+	}
+	if len(g.RoundWinnerIDs) != 0 || g.TimedRounds.CurrentRound != 1 {
+		t.Errorf("restart left winners %q on round %d", g.RoundWinnerIDs, g.TimedRounds.CurrentRound)
+	}
+}
+
+func TestGame_AddSpectator(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	watcher := g.AddSpectator("sam")
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	if g.OwnerID != alice.ID {
+		t.Errorf("OwnerID = %q, want the first player, not the spectator", g.OwnerID)
+	}
+	if g.PlayerCount() != 2 {
+		t.Errorf("PlayerCount = %d, want spectators excluded", g.PlayerCount())
+	}
+	if err := g.ReadyUp(watcher.ID, now); !errors.Is(err, ErrSpectator) {
+		t.Errorf("spectator ReadyUp err = %v, want ErrSpectator", err)
+	}
+	if err := g.ReadyUp(alice.ID, now); err != nil {
+		t.Fatalf("ReadyUp(alice) err = %v, want nil", err)
+	}
+	if err := g.ReadyUp(bob.ID, now); !errors.Is(err, ErrAutoStarted) {
+		t.Fatalf("ReadyUp err = %v, want ErrAutoStarted without waiting on the spectator", err)
+	}
+	if ok, err := g.SubmitGuess(watcher.ID, g.CurrentRoundData().Word, now); ok || !errors.Is(err, ErrSpectator) {
+		t.Errorf("spectator SubmitGuess = %t, %v; want false, ErrSpectator", ok, err)
+	}
+
+	snap := g.Snapshot(now)
+	if snap.SpectatorCount != 1 || len(snap.Spectators) != 1 || !snap.Spectators[0].IsSpectator || snap.Spectators[0].Name != "sam" {
+		t.Errorf("Spectators = %+v (count %d), want sam", snap.Spectators, snap.SpectatorCount)
+	}
+	if len(snap.Players) != 2 || len(snap.Scores) != 2 || len(snap.Progress) != 2 {
+		t.Errorf("players/scores/progress = %d/%d/%d, want spectators excluded", len(snap.Players), len(snap.Scores), len(snap.Progress))
+	}
+}
+
+func TestGame_RoundHistory(t *testing.T) {
+	start := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	_ = g.Start(start)
+
+	// Round 1: alice solves it.
+	first := g.CurrentRoundData()
+	if ok, err := g.SubmitGuess(alice.ID, first.Word, start.Add(10*time.Second)); !ok || err != nil {
+		t.Fatalf("SubmitGuess = %v, %v; want true, nil", ok, err)
+	}
+	if len(g.Snapshot(start.Add(11*time.Second)).RoundHistory) != 0 {
+		t.Fatal("history recorded before the cooldown ended")
+	}
+	round2Start := start.Add(time.Minute)
+	g.AdvanceIfNeeded(round2Start)
+	if g.TimedRounds.CurrentRound != 2 {
+		t.Fatalf("CurrentRound %d, want 2", g.TimedRounds.CurrentRound)
+	}
+
+	// Round 2: nobody solves it.
+	second := g.CurrentRoundData()
+	g.AdvanceIfNeeded(round2Start.Add(2 * time.Minute))
+	g.AdvanceIfNeeded(round2Start.Add(5 * time.Minute))
+	if g.Status != StatusFinished {
+		t.Fatalf("Status %q, want %q", g.Status, StatusFinished)
+	}
+
+	history := g.Snapshot(round2Start.Add(5 * time.Minute)).RoundHistory
+	if len(history) != 2 {
+		t.Fatalf("len(RoundHistory) = %d, want 2", len(history))
+	}
+	solved := history[0]
+	if solved.Round != 1 || solved.Word != first.Word || solved.Scrambled != first.Scrambled {
+		t.Errorf("round 1 = %+v, want word %q", solved, first.Word)
+	}
+	if solved.TimedOut || solved.Winner != "alice" || solved.WinnerPoints != alice.Points || solved.WinnerPoints == 0 {
+		t.Errorf("round 1 winner = %q (%d pts, timed out %t), want alice with %d pts", solved.Winner, solved.WinnerPoints, solved.TimedOut, alice.Points)
+	}
+	if !solved.SolvedAt.Equal(start.Add(10 * time.Second)) {
+		t.Errorf("round 1 SolvedAt = %v, want %v", solved.SolvedAt, start.Add(10*time.Second))
+	}
+	timedOut := history[1]
+	if timedOut.Round != 2 || timedOut.Word != second.Word {
+		t.Errorf("round 2 = %+v, want word %q", timedOut, second.Word)
+	}
+	if !timedOut.TimedOut || timedOut.Winner != "" || timedOut.WinnerPoints != 0 || !timedOut.SolvedAt.IsZero() {
+		t.Errorf("round 2 = %+v, want a timed-out round with no winner", timedOut)
+	}
+}
+
+func TestGame_ExtendRound_DoesNotChangeScoring(t *testing.T) {
+	start := time.Now().UTC()
+	plain := NewGame(1, time.Minute, "en")
+	plainPlayer := plain.AddPlayer("alice")
+	extended := NewGame(1, time.Minute, "en")
+	owner := extended.AddPlayer("alice")
+	_ = plain.Start(start)
+	_ = extended.Start(start)
+
+	for i := 0; i < 5; i++ {
+		_ = extended.ExtendRound(owner.ID, 30*time.Second, start)
+	}
+	if extended.TimedRounds.Extra != realtime.MaxExtra {
+		t.Errorf("Extra = %v after repeated extends, want capped at %v", extended.TimedRounds.Extra, realtime.MaxExtra)
+	}
+	guessAt := start.Add(40 * time.Second)
+	if ok, _ := plain.SubmitGuess(plainPlayer.ID, plain.CurrentRoundData().Word, guessAt); !ok {
+		t.Fatal("plain guess rejected")
+	}
+	if ok, _ := extended.SubmitGuess(owner.ID, extended.CurrentRoundData().Word, guessAt); !ok {
+		t.Fatal("extended guess rejected")
+	}
+	if owner.Points != plainPlayer.Points {
+		t.Errorf("extended round scored %d, want %d like an unextended one", owner.Points, plainPlayer.Points)
+	}
+}
+
+func TestSnapshot_PlayerCountExcludesSpectators(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	now := time.Now().UTC()
+	if snap := g.Snapshot(now); snap.PlayerCount() != 0 || snap.HasMinimumPlayers() {
+		t.Fatalf("empty game: PlayerCount %d, HasMinimumPlayers %t", snap.PlayerCount(), snap.HasMinimumPlayers())
+	}
+	g.AddSpectator("watcher")
+	if snap := g.Snapshot(now); snap.PlayerCount() != 0 || snap.HasMinimumPlayers() {
+		t.Errorf("spectator counted: PlayerCount %d, HasMinimumPlayers %t", snap.PlayerCount(), snap.HasMinimumPlayers())
+	}
+	for i := 0; i < MinPlayers; i++ {
+		g.AddPlayer("p" + strconv.Itoa(i))
+	}
+	if snap := g.Snapshot(now); snap.PlayerCount() != MinPlayers || !snap.HasMinimumPlayers() {
+		t.Errorf("with %d players: PlayerCount %d, HasMinimumPlayers %t", MinPlayers, snap.PlayerCount(), snap.HasMinimumPlayers())
+	}
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"dagame/pkg/realtime"
+)
+
+// ErrGameNotFound is returned by a Repository when it has no game with the ID.
+var ErrGameNotFound = errors.New("game not found")
+
+// Repository saves games so they survive a restart; internal/persistence has
+// a SQL implementation.
+type Repository interface {
+	Save(g *Game) error
+	Load(id string) (*Game, error)
+	Delete(id string) error
+}
+
+// SetRepository makes the store save a game to repo whenever it publishes an
+// update for it, and look games up in repo when they are not in memory. Call
+// it before serving requests.
+func (s *Store) SetRepository(repo Repository) {
+	s.repo = repo
+}
+
+// loadGame fetches a game missing from memory from the repository, registers
+// it and resumes its round loop.
+func (s *Store) loadGame(id string) (*Game, bool) {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	if room, ok := s.r.Get(id); ok {
+		return room.State, true
+	}
+	g, err := s.repo.Load(id)
+	if err != nil {
+		if !errors.Is(err, ErrGameNotFound) {
+			slog.Error("load game", slog.String("gameID", id), slog.Any("err", err))
+		}
+		return nil, false
+	}
+	s.r.Create(g.ID, g)
+	if g.IsActive() {
+		s.EnsureRoundLoop(g.ID, g)
+	}
+	return g, true
+}
+
+// saveGame writes g to the repository, if any. Failures are logged; the game
+// carries on in memory.
+func (s *Store) saveGame(g *Game) {
+	if s.repo == nil || g == nil {
+		return
+	}
+	if err := s.repo.Save(g); err != nil {
+		slog.Error("save game", slog.String("gameID", g.ID), slog.Any("err", err))
+	}
+}
+
+// savedGame is the stored form of a Game: every field that survives a
+// restart, listed explicitly so new fields are not persisted by accident.
+// Webhook URLs carry their own credentials and are left out, so games stop
+// notifying after a restart. The join password is only kept as its hash.
+type savedGame struct {
+	ID                   string
+	CreatedAt            time.Time
+	TimedRounds          realtime.TimedRounds
+	RoundData            []Round
+	Status               string
+	Lang                 string
+	Category             string
+	RoundWinnerIDs       []string
+	RoundSolvedAt        time.Time
+	OwnerID              string
+	PasswordHash         []byte `json:",omitempty"`
+	LegacyPassword       string `json:"Password,omitempty"` // plaintext, written before passwords were hashed
+	Players              map[string]*Player
+	JoinOrder            []string
+	ReadyPlayers         map[string]bool
+	BannedWords          []string
+	RoundEndReason       string
+	LastRoundDelta       map[string]int
+	RoundHistory         []RoundResult
+	TimeBonusMultiplier  float64
+	AllowMultipleWinners bool
+	FuzzyTolerance       int
+}
+
+// EncodeState serialises the game for a Repository. Webhooks, custom points
+// formulas and per-round bonus counts are not included.
+func (g *Game) EncodeState() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return json.Marshal(savedGame{
+		ID:                   g.ID,
+		CreatedAt:            g.CreatedAt,
+		TimedRounds:          g.TimedRounds,
+		RoundData:            g.RoundData,
+		Status:               g.Status,
+		Lang:                 g.Lang,
+		Category:             g.Category,
+		RoundWinnerIDs:       g.RoundWinnerIDs,
+		RoundSolvedAt:        g.RoundSolvedAt,
+		OwnerID:              g.OwnerID,
+		PasswordHash:         g.passwordHash,
+		Players:              g.Players,
+		JoinOrder:            g.JoinOrder,
+		ReadyPlayers:         g.ReadyPlayers,
+		BannedWords:          g.BannedWords,
+		RoundEndReason:       g.RoundEndReason,
+		LastRoundDelta:       g.LastRoundDelta,
+		RoundHistory:         g.RoundHistory,
+		TimeBonusMultiplier:  g.TimeBonusMultiplier,
+		AllowMultipleWinners: g.AllowMultipleWinners,
+		FuzzyTolerance:       g.FuzzyTolerance,
+	})
+}
+
+// DecodeState rebuilds a game saved with EncodeState.
+func DecodeState(data []byte) (*Game, error) {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	g := &Game{
+		ID:                   saved.ID,
+		CreatedAt:            saved.CreatedAt,
+		TimedRounds:          saved.TimedRounds,
+		RoundData:            saved.RoundData,
+		Status:               saved.Status,
+		Lang:                 saved.Lang,
+		Category:             saved.Category,
+		RoundWinnerIDs:       saved.RoundWinnerIDs,
+		RoundSolvedAt:        saved.RoundSolvedAt,
+		OwnerID:              saved.OwnerID,
+		passwordHash:         saved.PasswordHash,
+		Players:              saved.Players,
+		JoinOrder:            saved.JoinOrder,
+		ReadyPlayers:         saved.ReadyPlayers,
+		BannedWords:          saved.BannedWords,
+		RoundEndReason:       saved.RoundEndReason,
+		LastRoundDelta:       saved.LastRoundDelta,
+		RoundHistory:         saved.RoundHistory,
+		TimeBonusMultiplier:  saved.TimeBonusMultiplier,
+		AllowMultipleWinners: saved.AllowMultipleWinners,
+		FuzzyTolerance:       saved.FuzzyTolerance,
+	}
+	if saved.LegacyPassword != "" && len(g.passwordHash) == 0 {
+		if err := g.SetPassword(saved.LegacyPassword); err != nil {
+			return nil, err
+		}
+	}
+	if g.Players == nil {
+		g.Players = make(map[string]*Player)
+	}
+	for _, player := range g.Players {
+		if player.Handle == "" {
+			player.Handle = newID() // saved before players had handles
+		}
+	}
+	if g.ReadyPlayers == nil {
+		g.ReadyPlayers = make(map[string]bool)
+	}
+	if g.TimeBonusMultiplier == 0 {
+		g.TimeBonusMultiplier = 1.0
+	}
+	return g, nil
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memRepo is an in-memory Repository that stores encoded state, like a real
+// database would.
+type memRepo struct {
+	mu    sync.Mutex
+	state map[string][]byte
+}
+
+func newMemRepo() *memRepo { return &memRepo{state: make(map[string][]byte)} }
+
+func (m *memRepo) Save(g *Game) error {
+	data, err := g.EncodeState()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state[g.ID] = data
+	return nil
+}
+
+func (m *memRepo) Load(id string) (*Game, error) {
+	m.mu.Lock()
+	data, ok := m.state[id]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return DecodeState(data)
+}
+
+func (m *memRepo) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.state, id)
+	return nil
+}
+
+func TestGame_EncodeState_RoundTrip(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	if err := g.SetPassword("secret"); err != nil {
+		t.Fatal(err)
+	}
+	g.WebhookURL = "https://discord.test/api/webhooks/1/token"
+	g.Webhooks = []WebhookEntry{{URL: "https://hooks.test/token", Events: []string{"game_finished"}}}
+	alice := g.AddPlayer("alice")
+	g.AddSpectator("bob")
+	_ = g.Start(now)
+	if ok, _ := g.SubmitGuess(alice.ID, g.CurrentRoundData().Word, now.Add(time.Second)); !ok {
+		t.Fatal("correct guess rejected")
+	}
+
+	data, err := g.EncodeState()
+	if err != nil {
+		t.Fatalf("EncodeState: %v", err)
+	}
+	for _, secret := range []string{"secret", "token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("saved state contains %q: %s", secret, data)
+		}
+	}
+	got, err := DecodeState(data)
+	if err != nil {
+		t.Fatalf("DecodeState: %v", err)
+	}
+	if got.ID != g.ID || got.Status != StatusInProgress || got.OwnerID != alice.ID {
+		t.Errorf("decoded game = %+v, want same ID, status and owner", got)
+	}
+	if !got.IsProtected() || !got.CheckPassword("secret") || got.CheckPassword("guess") {
+		t.Error("password hash lost in round trip")
+	}
+	if got.WebhookURL != "" || len(got.Webhooks) != 0 {
+		t.Error("webhooks should not survive a restart")
+	}
+	if got.Players[alice.ID].Points != g.Players[alice.ID].Points || !slices.Equal(got.RoundWinnerIDs, []string{alice.ID}) {
+		t.Error("scores or round winner lost in round trip")
+	}
+	if got.PlayerCount() != 1 || len(got.Snapshot(now).Spectators) != 1 {
+		t.Error("player roles lost in round trip")
+	}
+	if !got.TimedRounds.RoundStarted.Equal(g.TimedRounds.RoundStarted) {
+		t.Error("round timing lost in round trip")
+	}
+}
+
+func TestDecodeState_HashesLegacyPassword(t *testing.T) {
+	got, err := DecodeState([]byte(`{"ID":"g1","Status":"lobby","Password":"secret"}`))
+	if err != nil {
+		t.Fatalf("DecodeState: %v", err)
+	}
+	if !got.CheckPassword("secret") || got.CheckPassword("") {
+		t.Error("plaintext password from an old save not honoured")
+	}
+	data, err := got.EncodeState()
+	if err != nil {
+		t.Fatalf("EncodeState: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("re-saved state still holds the plaintext password: %s", data)
+	}
+}
+
+func TestStore_SetRepository(t *testing.T) {
+	repo := newMemRepo()
+	s := NewStore()
+	s.SetRepository(repo)
+	g := s.CreateGame(1, time.Minute, "en")
+	if _, ok := repo.state[g.ID]; !ok {
+		t.Fatal("CreateGame did not save the game")
+	}
+	g.AddPlayer("alice")
+	s.Publish(g.ID, "players")
+
+	// A fresh store over the same repository sees the game after a "restart".
+	restarted := NewStore()
+	restarted.SetRepository(repo)
+	loaded, ok := restarted.GetGame(g.ID)
+	if !ok {
+		t.Fatal("GetGame did not load the saved game")
+	}
+	if names := loaded.PlayerNames(); len(names) != 1 || names[0] != "alice" {
+		t.Errorf("loaded players = %v, want [alice]", names)
+	}
+	if again, _ := restarted.GetGame(g.ID); again != loaded {
+		t.Error("second GetGame loaded a new copy instead of using memory")
+	}
+
+	restarted.DeleteGame(g.ID)
+	if _, ok := repo.state[g.ID]; ok {
+		t.Error("DeleteGame left the game in the repository")
+	}
+	if _, ok := restarted.GetGame(g.ID); ok {
+		t.Error("GetGame found a deleted game")
+	}
+	s.r.Delete(g.ID, deleteTimeout) // drop the original in-memory copy only
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// PointsFormula scores a correct guess made elapsed into a round of the given duration.
+type PointsFormula func(elapsed, duration time.Duration) int
+
+// defaultPoints awards 2 points before half time and 1 point after.
+func defaultPoints(elapsed, duration time.Duration) int {
+	if elapsed < duration/2 {
+		return 2
+	}
+	return 1
+}
+
+// timeBonusWindow is the fraction of a round during which TimeBonusMultiplier applies.
+const timeBonusWindow = 0.2
+
+// applyTimeBonus multiplies points, rounding up, when the guess landed inside
+// the bonus window and multiplier is above 1.
+func applyTimeBonus(points int, multiplier float64, elapsed, duration time.Duration) int {
+	if multiplier <= 1 || float64(elapsed) >= float64(duration)*timeBonusWindow {
+		return points
+	}
+	return int(math.Ceil(float64(points) * multiplier))
+}
+
+// LinearFormula awards maxPts for an instant guess, falling linearly to 1
+// point at the end of the round.
+func LinearFormula(maxPts int) PointsFormula {
+	return func(elapsed, duration time.Duration) int {
+		if duration <= 0 {
+			return 1
+		}
+		remaining := float64(duration-elapsed) / float64(duration)
+		points := int(math.Ceil(float64(maxPts) * remaining))
+		if points < 1 {
+			points = 1
+		}
+		return points
+	}
+}
+
+const (
+	// MaxBonusPoints caps a single AwardBonus call.
+	MaxBonusPoints = 10
+	// MaxBonusesPerRound caps how often one player can receive a bonus each round.
+	MaxBonusesPerRound = 3
+)
+
+// AwardBonus lets the owner hand out manual points while the game runs or after it finishes.
+func (g *Game) AwardBonus(ownerID, targetPlayerID string, points int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can award bonus points")
+	}
+	if g.Status == StatusLobby {
+		return errors.New("game has not started")
+	}
+	if points < 1 || points > MaxBonusPoints {
+		return fmt.Errorf("bonus must be between 1 and %d points", MaxBonusPoints)
+	}
+	player, ok := g.Players[targetPlayerID]
+	if !ok {
+		return errors.New("player not found")
+	}
+	if player.IsSpectator() {
+		return ErrSpectator
+	}
+	if g.bonusAwards == nil || g.bonusRound != g.TimedRounds.CurrentRound {
+		g.bonusAwards = make(map[string]int)
+		g.bonusRound = g.TimedRounds.CurrentRound
+	}
+	if g.bonusAwards[targetPlayerID] >= MaxBonusesPerRound {
+		return errors.New("bonus limit reached for this round")
+	}
+	g.bonusAwards[targetPlayerID]++
+	player.Points += points
+	clampPoints(player)
+	return nil
+}
+
+// clampPoints keeps a player's score from dropping below zero. Call it after
+// every change to Player.Points.
+func clampPoints(p *Player) {
+	if p.Points < 0 {
+		p.Points = 0
+	}
+}
+
+// SetPointsFormula replaces the scoring used by SubmitGuess; nil restores the default.
+func (g *Game) SetPointsFormula(fn PointsFormula) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pointsFormula = fn
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGame_SetPointsFormula(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	p := g.AddPlayer("alice")
+	g.SetPointsFormula(func(_, _ time.Duration) int { return 5 })
+	_ = g.Start(now)
+
+	ok, err := g.SubmitGuess(p.ID, g.CurrentRoundData().Word, now)
+	if err != nil || !ok {
+		t.Fatalf("SubmitGuess: ok=%v err=%v", ok, err)
+	}
+	if p.Points != 5 {
+		t.Errorf("Points = %d, want 5 from custom formula", p.Points)
+	}
+}
+
+func TestLinearFormula(t *testing.T) {
+	f := LinearFormula(10)
+	tests := []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 10},
+		{30 * time.Second, 5},
+		{59 * time.Second, 1},
+		{time.Minute, 1},
+		{2 * time.Minute, 1},
+	}
+	for _, tt := range tests {
+		if got := f(tt.elapsed, time.Minute); got != tt.want {
+			t.Errorf("LinearFormula(10)(%v, 1m) = %d, want %d", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestSubmitGuess_TimeBonus(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	p := g.AddPlayer("alice")
+	g.TimeBonusMultiplier = 3
+	g.SetPointsFormula(func(_, _ time.Duration) int { return 1 })
+	_ = g.Start(now)
+
+	ok, err := g.SubmitGuess(p.ID, g.CurrentRoundData().Word, now.Add(6*time.Second))
+	if err != nil || !ok {
+		t.Fatalf("SubmitGuess: ok=%v err=%v", ok, err)
+	}
+	if p.Points != 3 {
+		t.Errorf("Points = %d, want 3 at 10%% elapsed", p.Points)
+	}
+}
+
+func TestApplyTimeBonus(t *testing.T) {
+	if got := applyTimeBonus(2, 1.5, 5*time.Second, time.Minute); got != 3 {
+		t.Errorf("1.5x of 2 = %d, want 3", got)
+	}
+	if got := applyTimeBonus(2, 3, 12*time.Second, time.Minute); got != 2 {
+		t.Errorf("bonus after 20%% = %d, want 2", got)
+	}
+	if got := applyTimeBonus(2, 1, 0, time.Minute); got != 2 {
+		t.Errorf("multiplier 1 = %d, want 2", got)
+	}
+}
+
+func TestGame_AwardBonus(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	if err := g.AwardBonus(owner.ID, bob.ID, 1); err == nil {
+		t.Error("AwardBonus in lobby should fail")
+	}
+	_ = g.Start(now)
+	if err := g.AwardBonus(bob.ID, owner.ID, 1); err == nil {
+		t.Error("non-owner AwardBonus should fail")
+	}
+	if err := g.AwardBonus(owner.ID, bob.ID, MaxBonusPoints+1); err == nil {
+		t.Error("AwardBonus above the cap should fail")
+	}
+	for i := 0; i < MaxBonusesPerRound; i++ {
+		if err := g.AwardBonus(owner.ID, bob.ID, 2); err != nil {
+			t.Fatalf("AwardBonus #%d: %v", i+1, err)
+		}
+	}
+	if err := g.AwardBonus(owner.ID, bob.ID, 2); err == nil {
+		t.Error("AwardBonus past the per-round limit should fail")
+	}
+	if bob.Points != 2*MaxBonusesPerRound {
+		t.Errorf("bob.Points = %d, want %d", bob.Points, 2*MaxBonusesPerRound)
+	}
+}
+
+func TestPoints_NeverGoNegative(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	p := g.AddPlayer("alice")
+	_ = g.Start(now)
+
+	// Hint penalty at zero points.
+	if _, err := g.RequestHint(p.ID, now); err != nil {
+		t.Fatalf("RequestHint: %v", err)
+	}
+	if p.Points != 0 {
+		t.Errorf("after hint at 0 points: Points = %d, want 0", p.Points)
+	}
+
+	// A formula that penalises correct guesses.
+	g.SetPointsFormula(func(_, _ time.Duration) int { return -5 })
+	if _, err := g.SubmitGuess(p.ID, g.CurrentRoundData().Word, now); err != nil {
+		t.Fatalf("SubmitGuess: %v", err)
+	}
+	if p.Points != 0 {
+		t.Errorf("after negative formula: Points = %d, want 0", p.Points)
+	}
+
+	// Bonuses build on the floored score rather than a hidden negative balance.
+	if err := g.AwardBonus(p.ID, p.ID, 1); err != nil {
+		t.Fatalf("AwardBonus: %v", err)
+	}
+	if p.Points != 1 {
+		t.Errorf("after bonus: Points = %d, want 1", p.Points)
+	}
+}
+
+func TestGame_Scores(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	g.AddPlayer("bob").Points = 2
+	alice := g.AddPlayer("alice")
+	alice.Points = 5
+
+	scores := g.Scores()
+	if len(scores) != 2 || scores[0].PlayerID != alice.ID || scores[1].Name != "bob" {
+		t.Errorf("Scores() = %+v", scores)
+	}
+}
+
+func TestGame_LastRoundDelta(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	_ = g.Start(now)
+	if ok, _ := g.SubmitGuess(alice.ID, g.CurrentRoundData().Word, now); !ok {
+		t.Fatal("correct guess rejected")
+	}
+
+	snap := g.Snapshot(now)
+	if len(snap.LastRoundDelta) != 1 || snap.LastRoundDelta[0].Name != "alice" || snap.LastRoundDelta[0].Delta != alice.Points {
+		t.Fatalf("LastRoundDelta = %+v, want alice +%d", snap.LastRoundDelta, alice.Points)
+	}
+	if snap.Scores[0].Delta != alice.Points {
+		t.Errorf("Scores[0].Delta = %d, want %d", snap.Scores[0].Delta, alice.Points)
+	}
+
+	next := g.Snapshot(snap.NextRoundAt.Add(time.Millisecond))
+	if next.CurrentRound != 2 || len(next.LastRoundDelta) != 0 {
+		t.Errorf("round %d LastRoundDelta = %+v, want empty after the round starts", next.CurrentRound, next.LastRoundDelta)
+	}
+}
+
+func TestSubmitGuess_MultipleWinners(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(2, time.Minute, "en")
+	g.AllowMultipleWinners = true
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	carol := g.AddPlayer("carol")
+	dave := g.AddPlayer("dave")
+	_ = g.Start(now)
+	word := g.CurrentRoundData().Word
+
+	for i, p := range []*Player{alice, bob, carol} {
+		ok, err := g.SubmitGuess(p.ID, word, now.Add(time.Duration(i+1)*time.Second))
+		if err != nil || !ok {
+			t.Fatalf("%s SubmitGuess: ok=%v err=%v", p.Username, ok, err)
+		}
+	}
+	if ok, _ := g.SubmitGuess(alice.ID, word, now.Add(4*time.Second)); ok {
+		t.Error("alice scored twice in one round")
+	}
+	for _, tt := range []struct {
+		p    *Player
+		want int
+	}{{alice, 2}, {bob, 1}, {carol, 1}, {dave, 0}} {
+		if tt.p.Points != tt.want {
+			t.Errorf("%s has %d points, want %d", tt.p.Username, tt.p.Points, tt.want)
+		}
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		t.Fatal("round ended before dave solved it or time ran out")
+	}
+	snap := g.Snapshot(now.Add(5 * time.Second))
+	if snap.RoundWinner != "alice" || !slices.Equal(snap.RoundWinners, []string{"alice", "bob", "carol"}) {
+		t.Errorf("RoundWinner %q, RoundWinners %q", snap.RoundWinner, snap.RoundWinners)
+	}
+
+	// The last player to solve closes the round.
+	if ok, _ := g.SubmitGuess(dave.ID, word, now.Add(6*time.Second)); !ok {
+		t.Fatal("dave's correct guess was rejected")
+	}
+	if g.TimedRounds.RoundEndedAt.IsZero() || g.RoundEndReason != "solved" {
+		t.Errorf("round still open after everyone solved (reason %q)", g.RoundEndReason)
+	}
+}
+
+func TestSubmitGuess_SingleWinnerLocksRound(t *testing.T) {
+	now := time.Now().UTC()
+	g := NewGame(1, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	_ = g.Start(now)
+	word := g.CurrentRoundData().Word
+
+	if ok, _ := g.SubmitGuess(alice.ID, word, now); !ok {
+		t.Fatal("alice's correct guess was rejected")
+	}
+	if ok, _ := g.SubmitGuess(bob.ID, word, now); ok {
+		t.Error("bob scored after alice locked the round")
+	}
+	if g.TimedRounds.RoundEndedAt.IsZero() {
+		t.Error("round did not end on the first correct guess")
+	}
+}
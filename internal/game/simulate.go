@@ -0,0 +1,70 @@
+package game
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// GuessEvent is one guess fed to SimulateRound.
+type GuessEvent struct {
+	PlayerID string
+	Guess    string
+	At       time.Time
+}
+
+// SimulationResult summarises a simulated round.
+type SimulationResult struct {
+	Round     int
+	WinnerID  string         // empty if nobody solved it
+	Points    map[string]int // player ID -> points gained (or lost) during the simulation
+	TimeTaken time.Duration  // from round start to the winning guess; zero if unsolved
+}
+
+// SimulateRound replays guesses against the current round through SubmitGuess,
+// using each event's timestamp instead of the clock, so scoring can be tested
+// without timers. Events are replayed in order; when rng is non-nil, events
+// sharing a timestamp are shuffled with it to model simultaneous arrivals.
+func (g *Game) SimulateRound(guesses []GuessEvent, rng *rand.Rand) SimulationResult {
+	events := append([]GuessEvent(nil), guesses...)
+	if rng != nil {
+		for start := 0; start < len(events); {
+			end := start + 1
+			for end < len(events) && events[end].At.Equal(events[start].At) {
+				end++
+			}
+			batch := events[start:end]
+			rng.Shuffle(len(batch), func(i, j int) { batch[i], batch[j] = batch[j], batch[i] })
+			start = end
+		}
+	}
+
+	before := g.pointsByPlayer()
+	g.mu.Lock()
+	result := SimulationResult{Round: g.TimedRounds.CurrentRound}
+	g.mu.Unlock()
+	for _, e := range events {
+		if ok, _ := g.SubmitGuess(e.PlayerID, e.Guess, e.At); ok {
+			g.mu.Lock()
+			result.WinnerID = g.RoundWinnerIDs[0]
+			result.TimeTaken = g.RoundSolvedAt.Sub(g.TimedRounds.RoundStarted)
+			g.mu.Unlock()
+		}
+	}
+	result.Points = make(map[string]int)
+	for id, pts := range g.pointsByPlayer() {
+		if delta := pts - before[id]; delta != 0 {
+			result.Points[id] = delta
+		}
+	}
+	return result
+}
+
+func (g *Game) pointsByPlayer() map[string]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	points := make(map[string]int, len(g.Players))
+	for id, player := range g.Players {
+		points[id] = player.Points
+	}
+	return points
+}
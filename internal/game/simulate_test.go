@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestGame_SimulateRound(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGame(1, time.Minute, "en")
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	g.TimeBonusMultiplier = 3
+	_ = g.Start(start)
+	word := g.CurrentRoundData().Word
+
+	result := g.SimulateRound([]GuessEvent{
+		{PlayerID: bob.ID, Guess: "nope", At: start.Add(time.Second)},
+		{PlayerID: alice.ID, Guess: word, At: start.Add(6 * time.Second)},
+		{PlayerID: bob.ID, Guess: word, At: start.Add(7 * time.Second)},
+	}, nil)
+
+	if result.WinnerID != alice.ID {
+		t.Errorf("WinnerID = %q, want alice", result.WinnerID)
+	}
+	if result.TimeTaken != 6*time.Second {
+		t.Errorf("TimeTaken = %v, want 6s", result.TimeTaken)
+	}
+	// Default 2 points before half time, tripled inside the first 20%.
+	if result.Points[alice.ID] != 6 || result.Points[bob.ID] != 0 {
+		t.Errorf("Points = %v, want alice 6 and bob 0", result.Points)
+	}
+}
+
+func TestGame_SimulateRound_SameSeedSameWinner(t *testing.T) {
+	play := func(seed uint64) string {
+		start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		g := NewGame(1, time.Minute, "en")
+		names := []string{"alice", "bob", "carol", "dave"}
+		var guesses []GuessEvent
+		_ = g.Start(start)
+		word := g.CurrentRoundData().Word
+		for _, name := range names {
+			guesses = append(guesses, GuessEvent{PlayerID: g.AddPlayer(name).ID, Guess: word, At: start.Add(time.Second)})
+		}
+		result := g.SimulateRound(guesses, rand.New(rand.NewPCG(seed, 1)))
+		name, _ := g.PlayerName(result.WinnerID)
+		return name
+	}
+	for seed := uint64(0); seed < 5; seed++ {
+		if a, b := play(seed), play(seed); a == "" || a != b {
+			t.Errorf("seed %d: winners %q and %q, want the same non-empty winner", seed, a, b)
+		}
+	}
+}
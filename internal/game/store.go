@@ -1,14 +1,20 @@
 package game
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base32"
 	"errors"
+	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
+
+	"dagame/internal/metrics"
 	"dagame/pkg/realtime"
 )
 
@@ -18,20 +24,95 @@ const (
 	StatusFinished   = "finished"
 )
 
+// MaxRounds caps how many rounds the owner can configure from the lobby.
+const MaxRounds = 10
+
+// MinPlayers is the smallest lobby that ReadyUp will auto-start.
+const MinPlayers = 2
+
+// InactivityThreshold is how long a player in a running game may go without
+// a request before the round loop removes them. Open streams touch the player
+// on every heartbeat, so only closed tabs go quiet this long.
+const InactivityThreshold = 2 * time.Minute
+
+// ErrAutoStarted is returned by ReadyUp when the last ready player started the game.
+var ErrAutoStarted = errors.New("all players ready; game started")
+
+// ErrPlayerMuted is returned by SubmitGuess when the owner has muted the player.
+var ErrPlayerMuted = errors.New("player is muted")
+
+// ErrSpectator is returned when a spectator attempts a player-only action.
+var ErrSpectator = errors.New("spectators cannot play")
+
+// ErrNotOwner is returned when someone other than the owner attempts an owner-only action.
+var ErrNotOwner = errors.New("only the owner can do that")
+
+// Player roles. Spectators watch the game without scoring, guessing or
+// counting towards MinPlayers.
+const (
+	RolePlayer    = "player"
+	RoleSpectator = "spectator"
+)
+
 // Store holds games and delegates to realtime.RoomStore for persistence and broadcast.
 type Store struct {
 	r *realtime.RoomStore[*Game]
+
+	onRoundAdvance func(gameID string) // set before any round loop starts
+	onGameDeleted  func(gameID string) // set before serving requests
+
+	repo   Repository // optional; see SetRepository
+	loadMu sync.Mutex // serialises repository loads so a game is registered once
+
+	metrics *metrics.Collector // optional; see WithMetrics
 }
 
 // NewStore creates an in-memory game store with SSE broadcasters.
-func NewStore() *Store {
-	return &Store{r: realtime.NewRoomStore[*Game]()}
+func NewStore(opts ...Option) *Store {
+	s := &Store{r: realtime.NewRoomStore[*Game]()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.metrics.SetGaugeSource(s.gauges)
+	return s
+}
+
+// Option configures a Store; see NewStore.
+type Option func(*Store)
+
+// WithMetrics reports store activity to c.
+func WithMetrics(c *metrics.Collector) Option {
+	return func(s *Store) { s.metrics = c }
+}
+
+// Metrics returns the store's collector, or nil. Collector methods are safe
+// to call on nil.
+func (s *Store) Metrics() *metrics.Collector {
+	return s.metrics
+}
+
+// gauges reads the current game, stream and loop counts for the collector.
+func (s *Store) gauges() metrics.Gauges {
+	rooms := s.r.Rooms()
+	g := metrics.Gauges{ActiveGames: len(rooms), RoundLoops: s.r.LoopCount()}
+	for _, room := range rooms {
+		g.Subscribers += room.Subscribers()
+	}
+	return g
 }
 
 // CreateGame initializes a game and registers its broadcaster.
 func (s *Store) CreateGame(rounds int, duration time.Duration, lang string) *Game {
-	g := NewGame(rounds, duration, lang)
+	return s.CreateGameWithCategory(rounds, duration, lang, "")
+}
+
+// CreateGameWithCategory is CreateGame with words drawn from category; see
+// BuildRoundsWithCategory.
+func (s *Store) CreateGameWithCategory(rounds int, duration time.Duration, lang, category string) *Game {
+	g := newGame(rounds, duration, lang, category)
 	s.r.Create(g.ID, g)
+	s.saveGame(g)
+	s.metrics.GameCreated()
 	return g
 }
 
@@ -39,19 +120,108 @@ func (s *Store) CreateGame(rounds int, duration time.Duration, lang string) *Gam
 func (s *Store) GetGame(id string) (*Game, bool) {
 	room, ok := s.r.Get(id)
 	if !ok {
+		if s.repo != nil {
+			return s.loadGame(id)
+		}
 		return nil, false
 	}
 	return room.State, ok
 }
 
+// deleteTimeout bounds how long DeleteGame waits for the round loop to exit.
+const deleteTimeout = 2 * time.Second
+
+// DeleteGame stops the game's round loop, closes its broadcaster (ending open
+// streams) and removes it from the store. Deleting an unknown ID is a no-op.
+//
+// It is the primary cleanup path: anything that retires games should call it
+// rather than touching the store's internals. StartCleanupLoop removes expired
+// games through it, so OnGameDeleted hooks see those deletions too. Tests that
+// call CreateGame should register t.Cleanup(func() { s.DeleteGame(g.ID) }) so
+// loops don't outlive the test.
+func (s *Store) DeleteGame(id string) {
+	if !s.r.Delete(id, deleteTimeout) {
+		slog.Warn("delete game: round loop did not exit", slog.String("gameID", id), slog.Duration("timeout", deleteTimeout))
+	}
+	if s.repo != nil {
+		if err := s.repo.Delete(id); err != nil {
+			slog.Error("delete game from repository", slog.String("gameID", id), slog.Any("err", err))
+		}
+	}
+	if s.onGameDeleted != nil {
+		s.onGameDeleted(id)
+	}
+}
+
+// Close deletes every game, stopping round loops and ending open streams.
+// Call it once the HTTP server has shut down.
+func (s *Store) Close() error {
+	return s.r.Close()
+}
+
+// Shutdown lets open streams receive in-flight events, waiting until ctx ends
+// at most, then closes the store like Close.
+func (s *Store) Shutdown(ctx context.Context) error {
+	return errors.Join(s.r.Drain(ctx), s.r.Close())
+}
+
+// cleanupInterval is the longest StartCleanupLoop waits between sweeps.
+const cleanupInterval = 10 * time.Minute
+
+// StartCleanupLoop sweeps the store in the background until ctx is done,
+// removing every game for which IsExpired(maxAge) holds via DeleteGame. Sweeps
+// run every cleanupInterval, or every maxAge if that is shorter.
+func (s *Store) StartCleanupLoop(ctx context.Context, maxAge time.Duration) {
+	interval := min(maxAge, cleanupInterval)
+	if interval <= 0 {
+		interval = cleanupInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.deleteExpired(maxAge)
+			}
+		}
+	}()
+}
+
+// deleteExpired removes every game that IsExpired reports as expired.
+func (s *Store) deleteExpired(maxAge time.Duration) {
+	for _, room := range s.r.Rooms() {
+		if room.State != nil && room.State.IsExpired(maxAge) {
+			s.DeleteGame(room.ID)
+		}
+	}
+}
+
 // Broadcaster returns the SSE broadcaster for a game, creating it if missing.
-func (s *Store) Broadcaster(id string) *realtime.Broadcaster {
+func (s *Store) Broadcaster(id string) *realtime.HistoryBroadcaster {
 	return s.r.Broadcaster(id)
 }
 
+// ActiveConnectionCount returns how many streams are subscribed to a game, or
+// 0 if the game is unknown.
+func (s *Store) ActiveConnectionCount(gameID string) int {
+	room, ok := s.r.Get(gameID)
+	if !ok {
+		return 0
+	}
+	return room.Subscribers()
+}
+
 // Publish notifies subscribers of a game update with a typed event.
+// Handlers publish after every state change, so this is also where games are
+// saved when a repository is set.
 func (s *Store) Publish(id string, event string) {
 	s.r.Publish(id, event)
+	if room, ok := s.r.Get(id); ok {
+		s.saveGame(room.State)
+	}
 }
 
 // EnsureRoundLoop starts the timing loop for a game if not already running.
@@ -73,15 +243,107 @@ func (s *Store) EnsureRoundLoop(id string, _ *Game) {
 		}
 		advanced := state.AdvanceIfNeeded(now)
 		if advanced {
+			s.saveGame(state)
+			if s.onRoundAdvance != nil {
+				s.onRoundAdvance(id)
+			}
 			next2, ok2 := state.NextTimer(now)
 			if !ok2 {
 				return time.Time{}, nil, true
 			}
 			return next2, []string{"round", "scores", "players"}, false
 		}
+		if state.IsActive() && len(state.PruneInactivePlayers(InactivityThreshold, now)) > 0 {
+			s.saveGame(state)
+			return next, []string{"players"}, false
+		}
 		return next, nil, false
 	}
-	s.r.RunLoop(id, getState, tick)
+	s.r.RunLoop(id, getState, tick, realtime.DefaultLoopOptions())
+}
+
+// TouchPlayer records that playerID made a request to gameID just now, so
+// the round loop does not prune them as inactive.
+func (s *Store) TouchPlayer(gameID, playerID string) {
+	if playerID == "" {
+		return
+	}
+	if room, ok := s.r.Get(gameID); ok {
+		room.State.Touch(playerID, time.Now().UTC())
+	}
+}
+
+// OnRoundAdvance registers fn to run whenever a game's round loop moves past
+// a round, after the game state has advanced. It must be called before any
+// round loop starts; a later call replaces the previous hook.
+func (s *Store) OnRoundAdvance(fn func(gameID string)) {
+	s.onRoundAdvance = fn
+}
+
+// OnGameDeleted registers fn to run after DeleteGame removes a game, so
+// per-game state kept outside the store can be dropped. A later call
+// replaces the previous hook.
+func (s *Store) OnGameDeleted(fn func(gameID string)) {
+	s.onGameDeleted = fn
+}
+
+// StoreStats aggregates the games held by a Store.
+type StoreStats struct {
+	GamesByStatus map[string]int `json:"games_by_status"`
+	TotalGames    int            `json:"total_games"`
+	TotalPlayers  int            `json:"total_players"`
+	Connections   int            `json:"connections"` // open SSE/WebSocket streams
+}
+
+// Stats counts games by status, players and open stream connections.
+func (s *Store) Stats() StoreStats {
+	stats := StoreStats{GamesByStatus: make(map[string]int)}
+	for _, room := range s.r.Rooms() {
+		stats.Connections += room.Subscribers()
+		g := room.State
+		if g == nil {
+			continue
+		}
+		g.mu.Lock()
+		stats.GamesByStatus[g.Status]++
+		stats.TotalPlayers += len(g.Players)
+		g.mu.Unlock()
+		stats.TotalGames++
+	}
+	return stats
+}
+
+// GameSummary is the operator's view of one game.
+type GameSummary struct {
+	ID           string    `json:"id"`
+	Status       string    `json:"status"`
+	PlayerCount  int       `json:"player_count"` // spectators included
+	CreatedAt    time.Time `json:"created_at"`
+	CurrentRound int       `json:"current_round"`
+}
+
+// ListActiveGames summarises every game that has not finished, oldest first.
+func (s *Store) ListActiveGames() []GameSummary {
+	var games []GameSummary
+	for _, room := range s.r.Rooms() {
+		g := room.State
+		if g == nil {
+			continue
+		}
+		g.mu.Lock()
+		if g.Status != StatusFinished {
+			games = append(games, GameSummary{
+				ID:           g.ID,
+				Status:       g.Status,
+				PlayerCount:  len(g.Players),
+				CreatedAt:    g.CreatedAt,
+				CurrentRound: g.TimedRounds.CurrentRound,
+			})
+		}
+		g.mu.Unlock()
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].CreatedAt.Before(games[j].CreatedAt) })
+	return games
 }
 
 // WakeRoundLoop unblocks the round loop so it recomputes (e.g. after early round end).
@@ -90,10 +352,15 @@ func (s *Store) WakeRoundLoop(id string) {
 }
 
 func NewGame(rounds int, duration time.Duration, lang string) *Game {
+	return newGame(rounds, duration, lang, "")
+}
+
+func newGame(rounds int, duration time.Duration, lang, category string) *Game {
 	if lang == "" {
 		lang = "en"
 	}
-	roundData := BuildRounds(lang, rounds)
+	category = strings.ToLower(strings.TrimSpace(category))
+	roundData := BuildRoundsWithCategory(lang, category, rounds)
 	return &Game{
 		ID:        newID(),
 		CreatedAt: time.Now().UTC(),
@@ -102,56 +369,135 @@ func NewGame(rounds int, duration time.Duration, lang string) *Game {
 			Duration: duration,
 			Cooldown: realtime.DefaultCooldown,
 		},
-		RoundData: roundData,
-		Status:    StatusLobby,
-		Lang:      lang,
-		Players:   make(map[string]*Player),
+		RoundData:    roundData,
+		Status:       StatusLobby,
+		Lang:         lang,
+		Category:     category,
+		Players:      make(map[string]*Player),
+		ReadyPlayers: make(map[string]bool),
+
+		TimeBonusMultiplier: 1.0,
 	}
 }
 
 // Game holds the state for a single session.
 type Game struct {
-	mu            sync.Mutex
-	ID            string
-	CreatedAt     time.Time
-	TimedRounds   realtime.TimedRounds // Rounds, Duration, Cooldown, CurrentRound, RoundStarted, RoundEndedAt
-	RoundData     []Round
-	Status        string
-	Lang          string
-	RoundWinnerID string
-	RoundSolvedAt time.Time
-	OwnerID       string
-	Players       map[string]*Player
+	mu             sync.Mutex
+	ID             string
+	CreatedAt      time.Time
+	TimedRounds    realtime.TimedRounds // Rounds, Duration, Cooldown, CurrentRound, RoundStarted, RoundEndedAt
+	RoundData      []Round
+	Status         string
+	Lang           string
+	Category       string    // word category rounds are drawn from; empty means any
+	RoundWinnerIDs []string  // players who solved the current round, first solver first
+	RoundSolvedAt  time.Time // when the first player solved the current round
+	OwnerID        string
+	passwordHash   []byte // bcrypt hash of the join password; empty when anyone may join
+	Players        map[string]*Player
+	JoinOrder      []string // player IDs in the order they joined
+	ReadyPlayers   map[string]bool
+	BannedWords    []string // lowercased words never dealt again
+	WebhookURL     string   // Discord webhook notified when the game finishes
+	Webhooks       []WebhookEntry
+	RoundEndReason string         // "solved" or "timeout" once the current round ends; empty while active
+	LastRoundDelta map[string]int // player ID -> points earned in the current or last round
+	RoundHistory   []RoundResult  // one entry per completed round, oldest first
+
+	// TimeBonusMultiplier scales points for guesses in the first 20% of a round; 1.0 disables it.
+	TimeBonusMultiplier float64
+	// AllowMultipleWinners keeps a round open after the first correct guess,
+	// so everyone can solve it until the timer runs out.
+	AllowMultipleWinners bool
+	// FuzzyTolerance is the edit distance within which a guess still counts,
+	// for half points; 0 requires an exact match. See SetFuzzyTolerance.
+	FuzzyTolerance int
+	pointsFormula  PointsFormula
+
+	bonusRound  int            // round the bonusAwards counts belong to
+	bonusAwards map[string]int // player ID -> manual bonuses awarded in bonusRound
+
+	pendingHooks []webhookDelivery // queued under g.mu, sent by deliverWebhooks once it is released
 }
 
 // Round describes a single word and its scrambled version.
 type Round struct {
 	Word      string
 	Scrambled string
+	Category  string // empty when the word list line had no category
+}
+
+// RoundResult records how a completed round went.
+type RoundResult struct {
+	Round        int
+	Word         string
+	Scrambled    string
+	Winner       string // first solver's name; empty if nobody solved it
+	WinnerPoints int    // points the first solver earned in the round
+	SolvedAt     time.Time
+	TimedOut     bool // nobody solved the round before the timer ran out
 }
 
 // Player tracks per-session state for a participant.
 type Player struct {
-	ID       string
-	Username string
-	JoinedAt time.Time
-	Points   int
-	Progress int
+	ID            string // secret: doubles as the player's session cookie
+	Handle        string // public ID for naming the player in forms and URLs
+	Username      string
+	JoinedAt      time.Time
+	Points        int
+	Progress      int
+	HintedIndices []int // letter positions revealed to this player this round
+	Muted         bool  // set by the owner; muted players cannot guess
+	Role          string
+	Color         string    // CSS hex colour for the player's name, from playerPalette
+	LastSeenAt    time.Time // last request from this player; see Store.TouchPlayer
+	IsBot         bool      // played by a Bot; never pruned and never handed ownership
+}
+
+// IsSpectator reports whether the player only watches.
+func (p *Player) IsSpectator() bool {
+	return p.Role == RoleSpectator
 }
 
 // AddPlayer registers a player and assigns ownership if unset.
 func (g *Game) AddPlayer(username string) *Player {
+	return g.AddPlayerAs(username, RolePlayer)
+}
+
+// AddSpectator registers a watcher who cannot guess or score.
+func (g *Game) AddSpectator(username string) *Player {
+	return g.AddPlayerAs(username, RoleSpectator)
+}
+
+// AddPlayerAs registers a participant with the given role; anything other than
+// RoleSpectator joins as a player. Spectators never own the game and are kept
+// out of JoinOrder.
+func (g *Game) AddPlayerAs(username, role string) *Player {
+	defer g.deliverWebhooks()
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if role != RoleSpectator {
+		role = RolePlayer
+	}
+	now := time.Now().UTC()
 	player := &Player{
-		ID:       newID(),
-		Username: username,
-		JoinedAt: time.Now().UTC(),
+		ID:         newID(),
+		Handle:     newID(),
+		Username:   username,
+		JoinedAt:   now,
+		Role:       role,
+		LastSeenAt: now,
 	}
+	player.Color = PlayerColor(player.ID)
 	g.Players[player.ID] = player
+	if player.IsSpectator() {
+		return player
+	}
+	g.JoinOrder = append(g.JoinOrder, player.ID)
 	if g.OwnerID == "" {
 		g.OwnerID = player.ID
 	}
+	g.fireWebhooksLocked(WebhookPlayerJoin)
 	return player
 }
 
@@ -159,36 +505,207 @@ func (g *Game) AddPlayer(username string) *Player {
 func (g *Game) Start(now time.Time) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	return g.startLocked(now)
+}
+
+func (g *Game) startLocked(now time.Time) error {
 	if g.Status != StatusLobby {
 		return errors.New("game already started")
 	}
 	g.Status = StatusInProgress
 	g.TimedRounds.Start(now)
-	g.RoundWinnerID = ""
+	g.RoundWinnerIDs = nil
 	g.RoundSolvedAt = time.Time{}
+	g.RoundEndReason = ""
+	g.LastRoundDelta = nil
+	g.RoundHistory = nil
 	for _, player := range g.Players {
 		player.Progress = 0
+		player.HintedIndices = nil
+	}
+	return nil
+}
+
+// ReadyUp marks a lobby player as ready. Once every player is ready and at
+// least MinPlayers have joined, the game starts and ErrAutoStarted is returned.
+func (g *Game) ReadyUp(playerID string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusLobby {
+		return errors.New("game already started")
+	}
+	player, ok := g.Players[playerID]
+	if !ok {
+		return errors.New("player not found")
+	}
+	if player.IsSpectator() {
+		return ErrSpectator
+	}
+	g.ReadyPlayers[playerID] = true
+	if len(g.JoinOrder) < MinPlayers {
+		return nil
+	}
+	for _, id := range g.JoinOrder {
+		if !g.ReadyPlayers[id] {
+			return nil
+		}
+	}
+	if err := g.startLocked(now); err != nil {
+		return err
+	}
+	return ErrAutoStarted
+}
+
+// ExtendRound gives the current round d more time, up to realtime.MaxExtra
+// per round in total. Only the owner may extend, and only while the round is
+// still running at now. Scoring still measures from the real round start.
+func (g *Game) ExtendRound(ownerID string, d time.Duration, now time.Time) error {
+	defer g.deliverWebhooks()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can extend the round")
+	}
+	g.advanceIfNeededLocked(now)
+	if g.Status != StatusInProgress {
+		return errors.New("game not in progress")
+	}
+	if !g.TimedRounds.RoundEndedAt.IsZero() {
+		return errors.New("round already ended")
+	}
+	if d <= 0 {
+		return errors.New("extension must be positive")
+	}
+	if g.TimedRounds.Extend(d) == 0 {
+		return errors.New("round cannot be extended any further")
+	}
+	return nil
+}
+
+// AddRound appends a freshly picked round while the game is in the lobby.
+func (g *Game) AddRound(ownerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can change rounds")
+	}
+	if g.Status != StatusLobby {
+		return errors.New("game already started")
+	}
+	if len(g.RoundData) >= MaxRounds {
+		return errors.New("maximum rounds reached")
+	}
+	g.RoundData = append(g.RoundData, g.freshRoundLocked())
+	g.TimedRounds.Rounds = len(g.RoundData)
+	return nil
+}
+
+// RemoveLastRound drops the final round while the game is in the lobby,
+// keeping at least one.
+func (g *Game) RemoveLastRound(ownerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can change rounds")
+	}
+	if g.Status != StatusLobby {
+		return errors.New("game already started")
+	}
+	if len(g.RoundData) <= 1 {
+		return errors.New("at least one round is required")
+	}
+	g.RoundData = g.RoundData[:len(g.RoundData)-1]
+	g.TimedRounds.Rounds = len(g.RoundData)
+	return nil
+}
+
+// BanWord stops word from being dealt. In the lobby every round is re-checked;
+// during a game only rounds after the current one are replaced.
+func (g *Game) BanWord(ownerID, word string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can ban words")
+	}
+	if g.Status == StatusFinished {
+		return errors.New("game is finished")
+	}
+	word = strings.ToLower(strings.TrimSpace(word))
+	if word == "" {
+		return errors.New("word required")
+	}
+	for _, w := range g.BannedWords {
+		if w == word {
+			return nil
+		}
+	}
+	g.BannedWords = append(g.BannedWords, word)
+	first := 0
+	if g.Status == StatusInProgress {
+		first = g.TimedRounds.CurrentRound // RoundData index of the next round
+	}
+	for i := first; i < len(g.RoundData); i++ {
+		if g.RoundData[i].Word == word {
+			g.RoundData[i] = g.freshRoundLocked()
+		}
 	}
 	return nil
 }
 
-// Restart resets rounds and scores while keeping the same session ID.
+// buildRoundsLocked deals count rounds in the game's language and category,
+// skipping banned words.
+func (g *Game) buildRoundsLocked(count int) []Round {
+	return buildRounds(g.Lang, g.Category, count, g.BannedWords)
+}
+
+// freshRoundLocked deals one round whose word is neither banned nor already
+// in RoundData, unless the word list has nothing else left. Must be called
+// with g.mu held.
+func (g *Game) freshRoundLocked() Round {
+	used := slices.Clone(g.BannedWords)
+	for _, round := range g.RoundData {
+		used = append(used, round.Word)
+	}
+	return buildRounds(g.Lang, g.Category, 1, used)[0]
+}
+
+// RestartOptions controls RestartWithOptions.
+type RestartOptions struct {
+	// RebuildWords deals a fresh word list; false replays the same words.
+	RebuildWords bool
+}
+
+// Restart resets rounds and scores with new words while keeping the same session ID.
 func (g *Game) Restart(now time.Time) {
+	g.RestartWithOptions(now, RestartOptions{RebuildWords: true})
+}
+
+// RestartWithOptions resets scores, progress and timing while keeping the same
+// session ID and its webhooks, optionally keeping the current words for a rematch.
+func (g *Game) RestartWithOptions(now time.Time, opts RestartOptions) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.RoundData = BuildRounds(g.Lang, g.TimedRounds.Rounds)
+	if opts.RebuildWords {
+		g.RoundData = g.buildRoundsLocked(g.TimedRounds.Rounds)
+	}
 	g.Status = StatusInProgress
 	g.TimedRounds.Start(now)
-	g.RoundWinnerID = ""
+	g.RoundWinnerIDs = nil
 	g.RoundSolvedAt = time.Time{}
+	g.RoundEndReason = ""
+	g.LastRoundDelta = nil
+	g.RoundHistory = nil
+	g.bonusAwards = nil
 	for _, player := range g.Players {
 		player.Points = 0
 		player.Progress = 0
+		player.HintedIndices = nil
 	}
 }
 
 // AdvanceIfNeeded moves the game to the next round if timing conditions are met.
 func (g *Game) AdvanceIfNeeded(now time.Time) bool {
+	defer g.deliverWebhooks()
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	return g.advanceIfNeededLocked(now)
@@ -200,19 +717,66 @@ func (g *Game) advanceIfNeededLocked(now time.Time) bool {
 	}
 	advanced, finished := g.TimedRounds.Advance(now)
 	if finished {
+		g.recordRoundLocked(g.TimedRounds.CurrentRound)
 		g.Status = StatusFinished
+		if g.WebhookURL != "" {
+			g.queueWebhookLocked(g.WebhookURL, FormatDiscordEmbed(g.webhookSummaryLocked()))
+		}
+		g.fireWebhooksLocked(WebhookGameEnd)
+		return true
+	}
+	if advanced && !g.TimedRounds.RoundEndedAt.IsZero() {
+		// Time ran out on the current round; the next one starts after the cooldown.
+		g.RoundEndReason = "timeout"
+		g.resetProgressLocked()
+		g.fireWebhooksLocked(WebhookRoundEnd)
 		return true
 	}
 	if advanced {
-		g.RoundWinnerID = ""
+		// Advance has already moved CurrentRound on to the new round.
+		g.recordRoundLocked(g.TimedRounds.CurrentRound - 1)
+		g.RoundWinnerIDs = nil
 		g.RoundSolvedAt = time.Time{}
-		for _, player := range g.Players {
-			player.Progress = 0
-		}
+		g.RoundEndReason = ""
+		g.LastRoundDelta = nil
+		g.resetProgressLocked()
 	}
 	return advanced
 }
 
+// resetProgressLocked clears every player's letter progress and hints.
+// Must be called with g.mu held.
+func (g *Game) resetProgressLocked() {
+	for _, player := range g.Players {
+		player.Progress = 0
+		player.HintedIndices = nil
+	}
+}
+
+// recordRoundLocked appends round's result to RoundHistory. It runs once the
+// round's cooldown is over, before the winners are reset for the next one.
+// Must be called with g.mu held.
+func (g *Game) recordRoundLocked(round int) {
+	if round < 1 || round > len(g.RoundData) {
+		return
+	}
+	result := RoundResult{
+		Round:     round,
+		Word:      g.RoundData[round-1].Word,
+		Scrambled: g.RoundData[round-1].Scrambled,
+		SolvedAt:  g.RoundSolvedAt,
+		TimedOut:  len(g.RoundWinnerIDs) == 0,
+	}
+	if !result.TimedOut {
+		winnerID := g.RoundWinnerIDs[0]
+		if winner, ok := g.Players[winnerID]; ok {
+			result.Winner = winner.Username
+		}
+		result.WinnerPoints = g.LastRoundDelta[winnerID]
+	}
+	g.RoundHistory = append(g.RoundHistory, result)
+}
+
 // CurrentRoundData returns the word data for the current round.
 func (g *Game) CurrentRoundData() Round {
 	g.mu.Lock()
@@ -229,6 +793,7 @@ func (g *Game) currentRoundDataLocked() Round {
 
 // SubmitGuess validates a guess, awards points, and ends the round on success.
 func (g *Game) SubmitGuess(playerID string, guess string, now time.Time) (bool, error) {
+	defer g.deliverWebhooks()
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	if g.Status != StatusInProgress {
@@ -244,35 +809,144 @@ func (g *Game) SubmitGuess(playerID string, guess string, now time.Time) (bool,
 	if !g.TimedRounds.RoundEndedAt.IsZero() {
 		return false, nil
 	}
-	if g.RoundWinnerID != "" {
+	if len(g.RoundWinnerIDs) > 0 && !g.AllowMultipleWinners {
+		return false, nil
+	}
+	if slices.Contains(g.RoundWinnerIDs, playerID) {
 		return false, nil
 	}
 	player, ok := g.Players[playerID]
 	if !ok {
 		return false, errors.New("player not found")
 	}
+	if player.IsSpectator() {
+		return false, ErrSpectator
+	}
+	if player.Muted {
+		return false, ErrPlayerMuted
+	}
 	normalized := strings.ToLower(strings.TrimSpace(guess))
 	normalized = strings.ReplaceAll(normalized, " ", "")
 	round := g.currentRoundDataLocked()
 	if normalized == "" || round.Word == "" {
 		return false, nil
 	}
+	fuzzy := false
 	if normalized != round.Word {
-		return false, nil
+		if g.FuzzyTolerance <= 0 || levenshtein(normalized, round.Word) > g.FuzzyTolerance {
+			return false, nil
+		}
+		fuzzy = true
+	}
+	formula := PointsFormula(defaultPoints)
+	if g.pointsFormula != nil {
+		formula = g.pointsFormula
+	}
+	elapsed := now.Sub(g.TimedRounds.RoundStarted)
+	points := formula(elapsed, g.TimedRounds.Duration)
+	points = applyTimeBonus(points, g.TimeBonusMultiplier, elapsed, g.TimedRounds.Duration)
+	if len(g.RoundWinnerIDs) > 0 {
+		points = laterWinnerPoints
 	}
-	points := 1
-	halfTime := g.TimedRounds.RoundStarted.Add(g.TimedRounds.Duration / 2)
-	if now.Before(halfTime) {
-		points = 2
+	if fuzzy {
+		points = max(points/2, 1)
 	}
+	before := player.Points
 	player.Points += points
+	clampPoints(player)
+	if g.LastRoundDelta == nil {
+		g.LastRoundDelta = make(map[string]int)
+	}
+	g.LastRoundDelta[playerID] += player.Points - before
 	player.Progress = len(round.Word)
-	g.RoundWinnerID = playerID
-	g.RoundSolvedAt = now
+	if len(g.RoundWinnerIDs) == 0 {
+		g.RoundSolvedAt = now
+	}
+	g.RoundWinnerIDs = append(g.RoundWinnerIDs, playerID)
+	if g.AllowMultipleWinners && !g.allSolvedLocked() {
+		return true, nil
+	}
+	g.RoundEndReason = "solved"
 	g.TimedRounds.RoundEndedAt = now
+	g.fireWebhooksLocked(WebhookRoundEnd)
 	return true, nil
 }
 
+// laterWinnerPoints is what every solver after the first earns when
+// AllowMultipleWinners is on.
+const laterWinnerPoints = 1
+
+// allSolvedLocked reports whether every non-spectator has solved the round.
+func (g *Game) allSolvedLocked() bool {
+	for id, player := range g.Players {
+		if !player.IsSpectator() && !slices.Contains(g.RoundWinnerIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSolved reports whether the player already solved the current round.
+func (g *Game) HasSolved(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return playerID != "" && slices.Contains(g.RoundWinnerIDs, playerID)
+}
+
+// RequestHint reveals the next unrevealed letter of the current word to one
+// player at a cost of 1 point (never below zero). It returns the word with the
+// player's hinted letters shown and the rest as underscores.
+func (g *Game) RequestHint(playerID string, now time.Time) (string, error) {
+	defer g.deliverWebhooks()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.Status != StatusInProgress {
+		return "", errors.New("game not in progress")
+	}
+	g.advanceIfNeededLocked(now)
+	if g.Status != StatusInProgress || !g.TimedRounds.RoundEndedAt.IsZero() {
+		return "", errors.New("round is over")
+	}
+	player, ok := g.Players[playerID]
+	if !ok {
+		return "", errors.New("player not found")
+	}
+	if player.IsSpectator() {
+		return "", ErrSpectator
+	}
+	word := []rune(g.currentRoundDataLocked().Word)
+	if len(word) == 0 {
+		return "", errors.New("round not started")
+	}
+	hinted := make(map[int]bool, len(player.HintedIndices))
+	for _, i := range player.HintedIndices {
+		hinted[i] = true
+	}
+	next := -1
+	for i := range word {
+		if !hinted[i] {
+			next = i
+			break
+		}
+	}
+	if next < 0 {
+		return "", errors.New("every letter is already revealed")
+	}
+	player.HintedIndices = append(player.HintedIndices, next)
+	hinted[next] = true
+	player.Points--
+	clampPoints(player)
+	revealed := make([]rune, len(word))
+	for i, r := range word {
+		if hinted[i] {
+			revealed[i] = r
+		} else {
+			revealed[i] = '_'
+		}
+	}
+	return string(revealed), nil
+}
+
 // NextTimer returns the next time the round state should advance.
 func (g *Game) NextTimer(now time.Time) (time.Time, bool) {
 	g.mu.Lock()
@@ -285,6 +959,7 @@ func (g *Game) NextTimer(now time.Time) (time.Time, bool) {
 
 // UpdateProgress stores a player's correct letter count for the current round.
 func (g *Game) UpdateProgress(playerID string, correct int, now time.Time) {
+	defer g.deliverWebhooks()
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	if g.Status != StatusInProgress {
@@ -305,7 +980,7 @@ func (g *Game) UpdateProgress(playerID string, correct int, now time.Time) {
 		correct = len(round.Word)
 	}
 	player, ok := g.Players[playerID]
-	if !ok {
+	if !ok || player.IsSpectator() || slices.Contains(g.RoundWinnerIDs, playerID) {
 		return
 	}
 	player.Progress = correct
@@ -329,13 +1004,274 @@ func (g *Game) IsOwner(playerID string) bool {
 	return playerID != "" && playerID == g.OwnerID
 }
 
-// PlayerNames returns a snapshot of all player names.
+// IsLobby reports whether the game is still waiting to start.
+func (g *Game) IsLobby() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusLobby
+}
+
+// IsActive reports whether rounds are being played.
+func (g *Game) IsActive() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusInProgress
+}
+
+// IsFinished reports whether the last round has ended.
+func (g *Game) IsFinished() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusFinished
+}
+
+// IsExpired reports whether the game has finished or is older than maxAge.
+func (g *Game) IsExpired(maxAge time.Duration) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Status == StatusFinished || time.Since(g.CreatedAt) > maxAge
+}
+
+// IsReady reports whether the player has readied up in the lobby.
+func (g *Game) IsReady(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.ReadyPlayers[playerID]
+}
+
+// MutePlayer stops a player from submitting guesses until UnmutePlayer.
+func (g *Game) MutePlayer(ownerID, targetID string) error {
+	return g.setMuted(ownerID, targetID, true)
+}
+
+// UnmutePlayer lets a muted player guess again.
+func (g *Game) UnmutePlayer(ownerID, targetID string) error {
+	return g.setMuted(ownerID, targetID, false)
+}
+
+func (g *Game) setMuted(ownerID, targetID string, muted bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return ErrNotOwner
+	}
+	player, ok := g.Players[targetID]
+	if !ok {
+		return errors.New("player not found")
+	}
+	player.Muted = muted
+	return nil
+}
+
+// KickPlayer removes targetID from the game. Only the owner may kick, and
+// may kick themselves; ownership then passes to the earliest-joined player
+// left, or is cleared when none remain.
+func (g *Game) KickPlayer(ownerID, targetID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can kick players")
+	}
+	if _, ok := g.Players[targetID]; !ok {
+		return errors.New("player not found")
+	}
+	if g.OwnerID == targetID {
+		if next := g.earliestJoinedLocked(targetID); next != "" {
+			if err := g.transferOwnershipLocked(ownerID, next); err != nil {
+				return err
+			}
+		} else {
+			g.OwnerID = ""
+		}
+	}
+	g.removePlayerLocked(targetID)
+	return nil
+}
+
+// removePlayerLocked drops playerID and every per-round record of them.
+// Ownership is the caller's concern.
+func (g *Game) removePlayerLocked(playerID string) {
+	delete(g.Players, playerID)
+	delete(g.ReadyPlayers, playerID)
+	delete(g.LastRoundDelta, playerID)
+	g.JoinOrder = slices.DeleteFunc(g.JoinOrder, func(id string) bool { return id == playerID })
+	g.RoundWinnerIDs = slices.DeleteFunc(g.RoundWinnerIDs, func(id string) bool { return id == playerID })
+}
+
+// Touch records that playerID was seen at now. Unknown IDs are ignored.
+func (g *Game) Touch(playerID string, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if player, ok := g.Players[playerID]; ok && now.After(player.LastSeenAt) {
+		player.LastSeenAt = now
+	}
+}
+
+// PruneInactivePlayers removes everyone last seen more than threshold before
+// now and returns their IDs. Bots make no requests, so they are never pruned.
+// If the owner is removed, ownership passes to the earliest-joined human left,
+// or is cleared when none remain.
+func (g *Game) PruneInactivePlayers(threshold time.Duration, now time.Time) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cutoff := now.Add(-threshold)
+	var pruned []string
+	for id, player := range g.Players {
+		if !player.IsBot && player.LastSeenAt.Before(cutoff) {
+			pruned = append(pruned, id)
+		}
+	}
+	sort.Strings(pruned)
+	for _, id := range pruned {
+		g.removePlayerLocked(id)
+	}
+	if _, ok := g.Players[g.OwnerID]; !ok && g.OwnerID != "" {
+		g.OwnerID = g.earliestJoinedLocked("")
+	}
+	return pruned
+}
+
+// TransferOwnership hands the game to newOwnerID. Only the current owner may
+// transfer, and only to a player (not a spectator) in the game.
+func (g *Game) TransferOwnership(currentOwnerID, newOwnerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.transferOwnershipLocked(currentOwnerID, newOwnerID)
+}
+
+func (g *Game) transferOwnershipLocked(currentOwnerID, newOwnerID string) error {
+	if currentOwnerID == "" || currentOwnerID != g.OwnerID {
+		return ErrNotOwner
+	}
+	if _, ok := g.Players[currentOwnerID]; !ok {
+		return errors.New("player not found")
+	}
+	next, ok := g.Players[newOwnerID]
+	if !ok {
+		return errors.New("player not found")
+	}
+	if next.IsSpectator() {
+		return ErrSpectator
+	}
+	g.OwnerID = newOwnerID
+	return nil
+}
+
+// earliestJoinedLocked returns the human non-spectator other than except with
+// the oldest JoinedAt, or "" if there is none.
+func (g *Game) earliestJoinedLocked(except string) string {
+	var next *Player
+	for _, id := range g.JoinOrder {
+		p := g.Players[id]
+		if p != nil && !p.IsBot && id != except && (next == nil || p.JoinedAt.Before(next.JoinedAt)) {
+			next = p
+		}
+	}
+	if next == nil {
+		return ""
+	}
+	return next.ID
+}
+
+// IsProtected reports whether joining requires a password.
+func (g *Game) IsProtected() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.passwordHash) > 0
+}
+
+// MaxPasswordLength is the longest join password in bytes; bcrypt ignores
+// anything past it, so SetPassword rejects longer ones.
+const MaxPasswordLength = 72
+
+// SetPassword sets the password new players need to join; empty lets anyone
+// join. Only a bcrypt hash is kept.
+func (g *Game) SetPassword(password string) error {
+	var hash []byte
+	if password != "" {
+		var err error
+		if hash, err = bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost); err != nil {
+			return err
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.passwordHash = hash
+	return nil
+}
+
+// CheckPassword reports whether password lets a new player join. Games
+// without a password accept anything.
+func (g *Game) CheckPassword(password string) bool {
+	g.mu.Lock()
+	hash := g.passwordHash
+	g.mu.Unlock()
+	if len(hash) == 0 {
+		return true
+	}
+	// bcrypt is deliberately slow, so compare without holding the lock.
+	return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil
+}
+
+// PlayerIDByHandle resolves a public handle, as posted by owner forms, to the
+// player's ID.
+func (g *Game) PlayerIDByHandle(handle string) (string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if handle == "" {
+		return "", false
+	}
+	for id, player := range g.Players {
+		if player.Handle == handle {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// PlayerHandle returns the public handle of playerID, or "" if unknown.
+func (g *Game) PlayerHandle(playerID string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if player, ok := g.Players[playerID]; ok {
+		return player.Handle
+	}
+	return ""
+}
+
+// IsMuted reports whether the owner has muted the player.
+func (g *Game) IsMuted(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	player, ok := g.Players[playerID]
+	return ok && player.Muted
+}
+
+// IsSpectator reports whether playerID joined as a spectator.
+func (g *Game) IsSpectator(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	player, ok := g.Players[playerID]
+	return ok && player.IsSpectator()
+}
+
+// PlayerCount returns the number of players, not counting spectators, without
+// exposing the Players map.
+func (g *Game) PlayerCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.JoinOrder)
+}
+
+// PlayerNames returns a snapshot of all player names in join order.
 func (g *Game) PlayerNames() []string {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	players := make([]string, 0, len(g.Players))
-	for _, player := range g.Players {
-		players = append(players, player.Username)
+	players := make([]string, 0, len(g.JoinOrder))
+	for _, id := range g.JoinOrder {
+		if player, ok := g.Players[id]; ok {
+			players = append(players, player.Username)
+		}
 	}
 	return players
 }
@@ -343,49 +1279,150 @@ func (g *Game) PlayerNames() []string {
 // Snapshot captures the state needed for rendering UI fragments.
 type Snapshot struct {
 	ID            string
+	Lang          string
 	Status        string
 	CurrentRound  int
 	Rounds        int
-	RoundDuration time.Duration
+	RoundDuration time.Duration // including any extension; RoundStarted+RoundDuration is the deadline
 	RoundStarted  time.Time
 	RoundData     Round
-	RoundWinner   string
+	RoundWinner   string   // first solver's name
+	RoundWinners  []string // every solver's name, first solver first
 	RoundEndedAt  time.Time
 	NextRoundAt   time.Time
-	Players       []string
-	Progress      []PlayerProgress
+	Players       []PlayerInfo     // in join order, spectators excluded
+	Progress      []PlayerProgress // most correct letters first
 	WordLength    int
 	Scores        []ScoreEntry
 	WinnerName    string
+	RevealedWord  string        // answer shown after a timeout or at game end; empty during active rounds
+	RoundHistory  []RoundResult // completed rounds, oldest first
+
+	EstimatedRemainingSec int // rough seconds until the game ends, or until it would if started now
+	PlayersInJoinOrder    []PlayerProgress
+	LastRoundDelta        []ScoreDelta // players who scored in the current or last round
+	Spectators            []PlayerInfo // in join order
+	SpectatorCount        int
+	IsProtected           bool // joining needs a password; the password itself is never included
+}
+
+// PlayerCount returns the number of players in the snapshot, not counting spectators.
+func (s Snapshot) PlayerCount() int {
+	return len(s.Players)
+}
+
+// HasMinimumPlayers reports whether enough players had joined to start the game.
+func (s Snapshot) HasMinimumPlayers() bool {
+	return s.PlayerCount() >= MinPlayers
 }
 
 // Snapshot returns a consistent view of the current game state.
 func (g *Game) Snapshot(now time.Time) Snapshot {
+	defer g.deliverWebhooks()
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	g.advanceIfNeededLocked(now)
-	players := make([]string, 0, len(g.Players))
+	snap := g.snapshotLocked()
+	snap.EstimatedRemainingSec = int(g.estimatedTimeRemainingLocked(now).Seconds())
+	return snap
+}
+
+// EstimatedTimeRemaining returns roughly how long the session has left: every
+// round and cooldown in the lobby, less whatever has elapsed once started.
+func (g *Game) EstimatedTimeRemaining() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.estimatedTimeRemainingLocked(time.Now().UTC())
+}
+
+func (g *Game) estimatedTimeRemainingLocked(now time.Time) time.Duration {
+	tr := g.TimedRounds
+	switch g.Status {
+	case StatusFinished:
+		return 0
+	case StatusLobby:
+		return time.Duration(tr.Rounds)*tr.Duration + time.Duration(tr.Rounds-1)*tr.Cooldown
+	}
+	var current time.Duration
+	if tr.RoundEndedAt.IsZero() {
+		current = tr.Deadline().Sub(now)
+	} else if !tr.IsLastRound() {
+		current = tr.RoundEndedAt.Add(tr.Cooldown).Sub(now)
+	}
+	if current < 0 {
+		current = 0
+	}
+	after := tr.RemainingRounds()
+	if tr.RoundEndedAt.IsZero() {
+		// The cooldown after the current round is still to come.
+		return current + time.Duration(after)*(tr.Duration+tr.Cooldown)
+	}
+	return current + time.Duration(after)*tr.Duration + time.Duration(max(after-1, 0))*tr.Cooldown
+}
+
+// Scores returns the scoreboard, highest first, without building a full Snapshot.
+func (g *Game) Scores() []ScoreEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.scoresLocked()
+}
+
+// scoresLocked builds the sorted scoreboard. Must be called with g.mu held.
+func (g *Game) scoresLocked() []ScoreEntry {
 	scores := make([]ScoreEntry, 0, len(g.Players))
-	progress := make([]PlayerProgress, 0, len(g.Players))
 	for _, player := range g.Players {
-		players = append(players, player.Username)
+		if player.IsSpectator() {
+			continue
+		}
 		scores = append(scores, ScoreEntry{
-			Name:   player.Username,
-			Points: player.Points,
-		})
-		progress = append(progress, PlayerProgress{
-			Name:    player.Username,
-			Correct: player.Progress,
+			PlayerID: player.ID,
+			Handle:   player.Handle,
+			Name:     player.Username,
+			Points:   player.Points,
+			Delta:    g.LastRoundDelta[player.ID],
+			Color:    player.Color,
 		})
 	}
 	sortScores(scores)
+	return scores
+}
+
+// snapshotLocked builds a Snapshot without advancing. Must be called with g.mu held.
+func (g *Game) snapshotLocked() Snapshot {
+	players := make([]PlayerInfo, 0, len(g.JoinOrder))
+	for _, id := range g.JoinOrder {
+		if player, ok := g.Players[id]; ok {
+			players = append(players, PlayerInfo{Name: player.Username, JoinedAt: player.JoinedAt, Ready: g.ReadyPlayers[id], Muted: player.Muted, Color: player.Color})
+		}
+	}
+	scores := g.scoresLocked()
+	progress := make([]PlayerProgress, 0, len(g.Players))
+	var spectators []PlayerInfo
+	for _, player := range g.Players {
+		if player.IsSpectator() {
+			spectators = append(spectators, PlayerInfo{Name: player.Username, JoinedAt: player.JoinedAt, IsSpectator: true, Color: player.Color})
+			continue
+		}
+		progress = append(progress, g.progressLocked(player))
+	}
+	sort.Slice(spectators, func(i, j int) bool { return spectators[i].JoinedAt.Before(spectators[j].JoinedAt) })
+	joinOrder := make([]PlayerProgress, 0, len(g.JoinOrder))
+	for _, id := range g.JoinOrder {
+		if player, ok := g.Players[id]; ok {
+			joinOrder = append(joinOrder, g.progressLocked(player))
+		}
+	}
 	sortProgress(progress)
-	roundWinner := ""
-	if g.RoundWinnerID != "" {
-		if winner, ok := g.Players[g.RoundWinnerID]; ok {
-			roundWinner = winner.Username
+	var roundWinners []string
+	for _, id := range g.RoundWinnerIDs {
+		if winner, ok := g.Players[id]; ok {
+			roundWinners = append(roundWinners, winner.Username)
 		}
 	}
+	roundWinner := ""
+	if len(roundWinners) > 0 {
+		roundWinner = roundWinners[0]
+	}
 	var nextRoundAt time.Time
 	if !g.TimedRounds.RoundEndedAt.IsZero() {
 		nextRoundAt = g.TimedRounds.RoundEndedAt.Add(g.TimedRounds.Cooldown)
@@ -398,35 +1435,103 @@ func (g *Game) Snapshot(now time.Time) Snapshot {
 	if round := g.currentRoundDataLocked(); round.Word != "" {
 		wordLength = len(round.Word)
 	}
+	revealedWord := ""
+	if g.RoundEndReason == "timeout" || g.Status == StatusFinished {
+		revealedWord = g.currentRoundDataLocked().Word
+	}
 	return Snapshot{
-		ID:            g.ID,
-		Status:        g.Status,
-		CurrentRound:  g.TimedRounds.CurrentRound,
-		Rounds:        g.TimedRounds.Rounds,
-		RoundDuration: g.TimedRounds.Duration,
-		RoundStarted:  g.TimedRounds.RoundStarted,
-		RoundData:     g.currentRoundDataLocked(),
-		RoundWinner:   roundWinner,
-		RoundEndedAt:  g.TimedRounds.RoundEndedAt,
-		NextRoundAt:   nextRoundAt,
-		Players:       players,
-		Progress:      progress,
-		WordLength:    wordLength,
-		Scores:        scores,
-		WinnerName:    winnerName,
+		ID:                 g.ID,
+		Lang:               g.Lang,
+		Status:             g.Status,
+		CurrentRound:       g.TimedRounds.CurrentRound,
+		Rounds:             g.TimedRounds.Rounds,
+		RoundDuration:      g.TimedRounds.Duration + g.TimedRounds.Extra,
+		RoundStarted:       g.TimedRounds.RoundStarted,
+		RoundData:          g.currentRoundDataLocked(),
+		RoundWinner:        roundWinner,
+		RoundWinners:       roundWinners,
+		RoundEndedAt:       g.TimedRounds.RoundEndedAt,
+		NextRoundAt:        nextRoundAt,
+		Players:            players,
+		Progress:           progress,
+		PlayersInJoinOrder: joinOrder,
+		LastRoundDelta:     g.lastRoundDeltaLocked(),
+		Spectators:         spectators,
+		SpectatorCount:     len(spectators),
+		WordLength:         wordLength,
+		Scores:             scores,
+		WinnerName:         winnerName,
+		RevealedWord:       revealedWord,
+		RoundHistory:       slices.Clone(g.RoundHistory),
+		IsProtected:        len(g.passwordHash) > 0,
 	}
 }
 
+// PlayerInfo is a player's name, when they joined and whether they are ready,
+// muted or only watching.
+type PlayerInfo struct {
+	Name        string
+	JoinedAt    time.Time
+	Ready       bool
+	Muted       bool
+	IsSpectator bool
+	Color       string // CSS hex colour for the player's name
+}
+
 // ScoreEntry represents a player's total points.
 type ScoreEntry struct {
-	Name   string
-	Points int
+	PlayerID string `json:"-"` // doubles as the player's cookie; never serialise it
+	Handle   string `json:"-"` // public ID for owner forms; see Game.PlayerIDByHandle
+	Name     string
+	Points   int
+	Delta    int    // points earned in the current or last round
+	Color    string // CSS hex colour for the player's name
+}
+
+// ScoreDelta is the points a player earned in the current or last round.
+type ScoreDelta struct {
+	Name  string
+	Delta int
+}
+
+// lastRoundDeltaLocked lists players who scored in the current or last round,
+// biggest gain first. Must be called with g.mu held.
+func (g *Game) lastRoundDeltaLocked() []ScoreDelta {
+	deltas := make([]ScoreDelta, 0, len(g.LastRoundDelta))
+	for id, delta := range g.LastRoundDelta {
+		if player, ok := g.Players[id]; ok && delta != 0 {
+			deltas = append(deltas, ScoreDelta{Name: player.Username, Delta: delta})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].Delta == deltas[j].Delta {
+			return deltas[i].Name < deltas[j].Name
+		}
+		return deltas[i].Delta > deltas[j].Delta
+	})
+	return deltas
 }
 
 // PlayerProgress represents a player's correct letter count.
 type PlayerProgress struct {
-	Name    string
-	Correct int
+	Name     string
+	Correct  int
+	Ready    bool // lobby ready-up state
+	JoinedAt time.Time
+	Muted    bool
+	Color    string // CSS hex colour for the player's name
+}
+
+// progressLocked builds a player's PlayerProgress. Must be called with g.mu held.
+func (g *Game) progressLocked(player *Player) PlayerProgress {
+	return PlayerProgress{
+		Name:     player.Username,
+		Correct:  player.Progress,
+		Ready:    g.ReadyPlayers[player.ID],
+		JoinedAt: player.JoinedAt,
+		Muted:    player.Muted,
+		Color:    player.Color,
+	}
 }
 
 func sortScores(scores []ScoreEntry) {
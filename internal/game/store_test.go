@@ -1,8 +1,11 @@
 package game
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"dagame/internal/metrics"
 )
 
 func TestNewStore(t *testing.T) {
@@ -15,6 +18,7 @@ func TestNewStore(t *testing.T) {
 func TestStore_CreateGame_GetGame(t *testing.T) {
 	s := NewStore()
 	g := s.CreateGame(2, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
 	if g == nil {
 		t.Fatal("CreateGame returned nil")
 	}
@@ -48,6 +52,7 @@ func TestStore_CreateGame_GetGame(t *testing.T) {
 func TestStore_Publish(t *testing.T) {
 	s := NewStore()
 	g := s.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
 	hub := s.Broadcaster(g.ID)
 	ch := hub.Subscribe()
 	defer hub.Unsubscribe(ch)
@@ -62,6 +67,7 @@ func TestStore_Publish(t *testing.T) {
 func TestStore_Broadcaster(t *testing.T) {
 	s := NewStore()
 	g := s.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
 	hub := s.Broadcaster(g.ID)
 	if hub == nil {
 		t.Fatal("Broadcaster returned nil for existing game")
@@ -76,6 +82,7 @@ func TestStore_Broadcaster(t *testing.T) {
 func TestStore_EnsureRoundLoop_DoesNotPanic(t *testing.T) {
 	s := NewStore()
 	g := s.CreateGame(1, 100*time.Millisecond, "en")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
 	g.AddPlayer("p1")
 	_ = g.Start(time.Now().UTC())
 
@@ -89,3 +96,143 @@ func TestStore_WakeRoundLoop_NoPanicWhenNoLoop(t *testing.T) {
 	// No EnsureRoundLoop called; Wake should not panic
 	s.WakeRoundLoop("nonexistent")
 }
+
+func TestStore_DeleteGame(t *testing.T) {
+	s := NewStore()
+	g := s.CreateGame(1, time.Minute, "en")
+	g.AddPlayer("alice")
+	if err := g.Start(time.Now().UTC()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s.EnsureRoundLoop(g.ID, g)
+	ch := s.Broadcaster(g.ID).Subscribe()
+
+	s.DeleteGame(g.ID)
+
+	if _, ok := s.GetGame(g.ID); ok {
+		t.Error("GetGame should return false after DeleteGame")
+	}
+	if _, open := <-ch; open {
+		t.Error("subscriber channel should be closed after DeleteGame")
+	}
+	s.DeleteGame(g.ID) // deleting twice is a no-op
+}
+
+func TestStore_Close_TerminatesAllLoops(t *testing.T) {
+	s := NewStore()
+	now := time.Now().UTC()
+	var dones []<-chan struct{}
+	for i := 0; i < 10; i++ {
+		g := s.CreateGame(3, time.Minute, "en")
+		g.AddPlayer("alice")
+		if err := g.Start(now); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		s.EnsureRoundLoop(g.ID, g)
+		dones = append(dones, s.r.LoopDone(g.ID))
+	}
+	if n := s.r.LoopCount(); n != 10 {
+		t.Fatalf("LoopCount = %d, want 10 round loops running", n)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	for i, done := range dones {
+		select {
+		case <-done:
+		default:
+			t.Errorf("loop %d still running after Close", i)
+		}
+	}
+	if stats := s.Stats(); stats.TotalGames != 0 {
+		t.Errorf("TotalGames = %d after Close, want 0", stats.TotalGames)
+	}
+}
+
+func TestGame_IsExpired(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	if g.IsExpired(time.Hour) {
+		t.Error("new lobby game should not be expired")
+	}
+	g.CreatedAt = time.Now().UTC().Add(-2 * time.Hour)
+	if !g.IsExpired(time.Hour) {
+		t.Error("game older than maxAge should be expired")
+	}
+	g.CreatedAt = time.Now().UTC()
+	g.Status = StatusFinished
+	if !g.IsExpired(time.Hour) {
+		t.Error("finished game should be expired")
+	}
+}
+
+func TestStore_StartCleanupLoop(t *testing.T) {
+	s := NewStore()
+	deleted := make(chan string, 1)
+	s.OnGameDeleted(func(id string) {
+		select {
+		case deleted <- id:
+		default:
+		}
+	})
+	finished := s.CreateGame(1, time.Minute, "en")
+	finished.AddPlayer("alice")
+	if err := finished.Start(time.Now().UTC()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s.EnsureRoundLoop(finished.ID, finished)
+	finished.mu.Lock()
+	finished.Status = StatusFinished
+	finished.mu.Unlock()
+	active := s.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { s.DeleteGame(active.ID) })
+
+	// A sweep with a long TTL removes only the finished game.
+	s.deleteExpired(time.Hour)
+	if _, ok := s.GetGame(finished.ID); ok {
+		t.Error("finished game should be removed")
+	}
+	select {
+	case id := <-deleted:
+		if id != finished.ID {
+			t.Errorf("OnGameDeleted got %s, want %s", id, finished.ID)
+		}
+	default:
+		t.Error("cleanup sweep should delete through DeleteGame")
+	}
+	if _, ok := s.GetGame(active.ID); !ok {
+		t.Error("active game within its TTL should be kept")
+	}
+
+	// The background loop removes games once they outlive a short TTL.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartCleanupLoop(ctx, 20*time.Millisecond)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := s.GetGame(active.ID); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cleanup loop did not remove the game after its TTL")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStore_WithMetrics(t *testing.T) {
+	c := metrics.New("test")
+	store := NewStore(WithMetrics(c))
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	sub := store.Broadcaster(g.ID).Subscribe()
+	defer store.Broadcaster(g.ID).Unsubscribe(sub)
+
+	snap := c.Snapshot()
+	if snap.GamesCreated != 1 {
+		t.Errorf("GamesCreated = %d, want 1", snap.GamesCreated)
+	}
+	if snap.ActiveGames != 1 || snap.Subscribers != 1 {
+		t.Errorf("gauges = %+v, want 1 game and 1 subscriber", snap.Gauges)
+	}
+}
@@ -0,0 +1,254 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// webhookTimeout bounds a single webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// webhookLookupTimeout bounds resolving a webhook host when it is registered.
+const webhookLookupTimeout = 5 * time.Second
+
+// webhookClient refuses to connect to non-public addresses, so a host that
+// resolved to a public IP at registration cannot be rebound to an internal one.
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{Timeout: webhookTimeout, Control: webhookDialControl}).DialContext,
+	},
+}
+
+// webhookIPAllowed reports whether webhooks may be delivered to ip. Tests
+// swap it to reach httptest servers on loopback.
+var webhookIPAllowed = isPublicIP
+
+// lookupWebhookHost resolves webhook hosts; tests swap it to avoid DNS.
+var lookupWebhookHost = net.DefaultResolver.LookupIPAddr
+
+// isPublicIP rejects loopback, private, link-local (including cloud metadata
+// endpoints), multicast and unspecified addresses.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// webhookDialControl aborts connections to addresses webhookIPAllowed rejects.
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !webhookIPAllowed(ip) {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
+
+// parseWebhookURL accepts an absolute https URL whose host resolves only to
+// public addresses.
+func parseWebhookURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return nil, errors.New("webhook URL must be an absolute https URL")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+	defer cancel()
+	addrs, err := lookupWebhookHost(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return nil, fmt.Errorf("webhook host %q does not resolve", u.Hostname())
+	}
+	for _, addr := range addrs {
+		if !webhookIPAllowed(addr.IP) {
+			return nil, errors.New("webhook URL must point to a public address")
+		}
+	}
+	return u, nil
+}
+
+// MaxWebhooks caps the event webhooks one game can register.
+const MaxWebhooks = 3
+
+// Events an event webhook can subscribe to.
+const (
+	WebhookRoundEnd   = "round_end"
+	WebhookGameEnd    = "game_end"
+	WebhookPlayerJoin = "player_join"
+)
+
+var webhookEvents = []string{WebhookRoundEnd, WebhookGameEnd, WebhookPlayerJoin}
+
+// WebhookEntry is an integration notified with a JSON POST for each listed event.
+type WebhookEntry struct {
+	URL    string
+	Events []string
+}
+
+// webhookPayload is the body sent to event webhooks.
+type webhookPayload struct {
+	Event    string   `json:"event"`
+	GameID   string   `json:"gameId"`
+	Snapshot Snapshot `json:"snapshot"`
+}
+
+// SetWebhook registers a Discord webhook to announce the final scores. Only
+// the owner may set it, and only before the game has finished.
+func (g *Game) SetWebhook(ownerID, rawURL string) error {
+	u, err := parseWebhookURL(rawURL)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can set a webhook")
+	}
+	if g.Status == StatusFinished {
+		return errors.New("game is finished")
+	}
+	g.WebhookURL = u.String()
+	return nil
+}
+
+// AddWebhook registers an event webhook. Only the owner may add one, the URL
+// must be https on a public address, and events must be non-empty and known.
+func (g *Game) AddWebhook(ownerID, rawURL string, events []string) error {
+	u, err := parseWebhookURL(rawURL)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return errors.New("at least one event required")
+	}
+	for _, event := range events {
+		if !slices.Contains(webhookEvents, event) {
+			return fmt.Errorf("unknown webhook event %q", event)
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ownerID == "" || ownerID != g.OwnerID {
+		return errors.New("only the owner can add webhooks")
+	}
+	if len(g.Webhooks) >= MaxWebhooks {
+		return fmt.Errorf("at most %d webhooks per game", MaxWebhooks)
+	}
+	g.Webhooks = append(g.Webhooks, WebhookEntry{URL: u.String(), Events: slices.Clone(events)})
+	return nil
+}
+
+// fireWebhooksLocked queues event for every webhook subscribed to it.
+// Must be called with g.mu held.
+func (g *Game) fireWebhooksLocked(event string) {
+	var targets []string
+	for _, hook := range g.Webhooks {
+		if slices.Contains(hook.Events, event) {
+			targets = append(targets, hook.URL)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	snap := g.snapshotLocked()
+	if g.Status != StatusFinished && g.TimedRounds.RoundEndedAt.IsZero() {
+		// Don't hand the answer to integrations while the round is still open.
+		snap.RoundData.Word = ""
+	}
+	payload, err := json.Marshal(webhookPayload{Event: event, GameID: g.ID, Snapshot: snap})
+	if err != nil {
+		slog.Error("webhook payload", slog.String("gameID", g.ID), slog.Any("err", err))
+		return
+	}
+	for _, target := range targets {
+		g.queueWebhookLocked(target, payload)
+	}
+}
+
+// webhookDelivery is a webhook post waiting for the game lock to be released.
+type webhookDelivery struct {
+	url     string
+	payload []byte
+}
+
+// queueWebhookLocked schedules payload for target. Must be called with g.mu held.
+func (g *Game) queueWebhookLocked(target string, payload []byte) {
+	g.pendingHooks = append(g.pendingHooks, webhookDelivery{url: target, payload: payload})
+}
+
+// deliverWebhooks sends the queued webhooks in the background. Methods that
+// may queue one defer it before taking g.mu, so it runs after the unlock.
+func (g *Game) deliverWebhooks() {
+	g.mu.Lock()
+	pending := g.pendingHooks
+	g.pendingHooks = nil
+	g.mu.Unlock()
+	for _, d := range pending {
+		go postWebhook(d.url, d.payload)
+	}
+}
+
+// FormatDiscordEmbed renders the final standings as a Discord webhook payload.
+func FormatDiscordEmbed(snap Snapshot) []byte {
+	var lines []string
+	for i, entry := range snap.Scores {
+		lines = append(lines, fmt.Sprintf("%d. **%s** — %d pts", i+1, entry.Name, entry.Points))
+	}
+	title := "Game over"
+	if snap.WinnerName != "" {
+		title = snap.WinnerName + " wins!"
+	}
+	payload := map[string]any{
+		"embeds": []map[string]any{{
+			"title":       title,
+			"description": strings.Join(lines, "\n"),
+			"footer":      map[string]string{"text": fmt.Sprintf("%d rounds · game %s", snap.Rounds, snap.ID)},
+		}},
+	}
+	b, _ := json.Marshal(payload)
+	return b
+}
+
+// webhookSummaryLocked fills only the Snapshot fields FormatDiscordEmbed reads.
+// Must be called with g.mu held.
+func (g *Game) webhookSummaryLocked() Snapshot {
+	scores := g.scoresLocked()
+	return Snapshot{
+		ID:         g.ID,
+		Rounds:     g.TimedRounds.Rounds,
+		Scores:     scores,
+		WinnerName: resolveWinner(scores),
+	}
+}
+
+// postWebhook delivers payload and logs failures; it runs off the game lock.
+// A 5xx response is retried once.
+func postWebhook(webhookURL string, payload []byte) {
+	for attempt := 1; ; attempt++ {
+		resp, err := webhookClient.Post(webhookURL, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			slog.Warn("webhook", slog.Any("err", err))
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 && attempt == 1 {
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			slog.Warn("webhook rejected", slog.String("url", webhookURL), slog.String("status", resp.Status))
+		}
+		return
+	}
+}
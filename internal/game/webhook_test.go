@@ -0,0 +1,225 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeWebhookDNS resolves hosts from addrs instead of DNS for the test.
+func fakeWebhookDNS(t *testing.T, addrs map[string]string) {
+	t.Helper()
+	prev := lookupWebhookHost
+	lookupWebhookHost = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		if ip := net.ParseIP(host); ip != nil {
+			return []net.IPAddr{{IP: ip}}, nil
+		}
+		if a, ok := addrs[host]; ok {
+			return []net.IPAddr{{IP: net.ParseIP(a)}}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	t.Cleanup(func() { lookupWebhookHost = prev })
+}
+
+// allowLoopbackWebhooks lets webhooks reach srv, an httptest server on loopback.
+func allowLoopbackWebhooks(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	prevAllowed, prevClient := webhookIPAllowed, webhookClient
+	webhookIPAllowed = func(net.IP) bool { return true }
+	webhookClient = srv.Client()
+	t.Cleanup(func() { webhookIPAllowed, webhookClient = prevAllowed, prevClient })
+}
+
+func TestFormatDiscordEmbed(t *testing.T) {
+	payload := FormatDiscordEmbed(Snapshot{
+		ID:         "abc",
+		Rounds:     2,
+		WinnerName: "alice",
+		Scores:     []ScoreEntry{{Name: "alice", Points: 7}, {Name: "bob", Points: 3}},
+	})
+	var body struct {
+		Embeds []struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"embeds"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Embeds) != 1 || body.Embeds[0].Title != "alice wins!" {
+		t.Fatalf("got %+v", body)
+	}
+	if !strings.Contains(body.Embeds[0].Description, "1. **alice** — 7 pts") {
+		t.Errorf("description %q", body.Embeds[0].Description)
+	}
+}
+
+func TestGame_WebhookFiresOnFinish(t *testing.T) {
+	got := make(chan string, 1)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got <- string(b)
+	}))
+	defer srv.Close()
+	allowLoopbackWebhooks(t, srv)
+
+	g := NewGame(1, time.Second, "en")
+	owner := g.AddPlayer("alice")
+	if err := g.SetWebhook(owner.ID, "http://insecure.example"); err == nil {
+		t.Error("plain http webhook should be rejected")
+	}
+	if err := g.SetWebhook(owner.ID, srv.URL); err != nil {
+		t.Fatalf("SetWebhook: %v", err)
+	}
+	start := time.Now().UTC()
+	_ = g.Start(start)
+	// The first advance times the round out; a later one finishes the game.
+	for i := 1; i <= 3; i++ {
+		g.AdvanceIfNeeded(start.Add(time.Duration(i) * time.Hour))
+	}
+	select {
+	case body := <-got:
+		if !strings.Contains(body, "alice") {
+			t.Errorf("payload %q missing player", body)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+}
+
+func TestGame_RestartKeepsWebhooks(t *testing.T) {
+	fakeWebhookDNS(t, map[string]string{"example.com": "93.184.216.34"})
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	if err := g.SetWebhook(owner.ID, "https://example.com/discord"); err != nil {
+		t.Fatalf("SetWebhook: %v", err)
+	}
+	if err := g.AddWebhook(owner.ID, "https://example.com/hook", []string{WebhookGameEnd}); err != nil {
+		t.Fatalf("AddWebhook: %v", err)
+	}
+	g.Restart(time.Now().UTC())
+	if g.WebhookURL != "https://example.com/discord" || len(g.Webhooks) != 1 {
+		t.Errorf("after Restart WebhookURL=%q Webhooks=%v, want both kept for the rematch", g.WebhookURL, g.Webhooks)
+	}
+}
+
+func TestGame_AddWebhook(t *testing.T) {
+	fakeWebhookDNS(t, map[string]string{"example.com": "93.184.216.34"})
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	guest := g.AddPlayer("bob")
+
+	if err := g.AddWebhook(guest.ID, "https://example.com/hook", []string{WebhookGameEnd}); err == nil {
+		t.Error("non-owner should not add webhooks")
+	}
+	if err := g.AddWebhook(owner.ID, "ftp://example.com/hook", []string{WebhookGameEnd}); err == nil {
+		t.Error("non-http scheme should be rejected")
+	}
+	if err := g.AddWebhook(owner.ID, "http://example.com/hook", []string{WebhookGameEnd}); err == nil {
+		t.Error("plain http should be rejected")
+	}
+	if err := g.AddWebhook(owner.ID, "https://example.com/hook", []string{"lunch"}); err == nil {
+		t.Error("unknown event should be rejected")
+	}
+	for i := 0; i < MaxWebhooks; i++ {
+		if err := g.AddWebhook(owner.ID, "https://example.com/hook", []string{WebhookGameEnd}); err != nil {
+			t.Fatalf("AddWebhook #%d: %v", i+1, err)
+		}
+	}
+	if err := g.AddWebhook(owner.ID, "https://example.com/hook", []string{WebhookGameEnd}); err == nil {
+		t.Errorf("webhook beyond MaxWebhooks should be rejected")
+	}
+}
+
+func TestGame_WebhookPlayerJoin_RetriesOn5xx(t *testing.T) {
+	got := make(chan string, 2)
+	calls := 0
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		got <- string(b)
+	}))
+	defer srv.Close()
+	allowLoopbackWebhooks(t, srv)
+
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	if err := g.AddWebhook(owner.ID, srv.URL, []string{WebhookPlayerJoin}); err != nil {
+		t.Fatalf("AddWebhook: %v", err)
+	}
+	bob := g.AddPlayer("bob")
+	select {
+	case body := <-got:
+		var payload struct {
+			Event  string `json:"event"`
+			GameID string `json:"gameId"`
+		}
+		if err := json.Unmarshal([]byte(body), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if payload.Event != WebhookPlayerJoin || payload.GameID != g.ID {
+			t.Errorf("payload %+v", payload)
+		}
+		if strings.Contains(body, bob.ID) {
+			t.Error("payload leaks a player ID")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not retried after a 5xx")
+	}
+}
+
+func TestGame_AddWebhook_RejectsInternalAddresses(t *testing.T) {
+	fakeWebhookDNS(t, map[string]string{
+		"public.example":   "93.184.216.34",
+		"internal.example": "10.0.0.5",
+	})
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+
+	for _, raw := range []string{
+		"https://127.0.0.1/hook",
+		"https://[::1]/hook",
+		"https://192.168.1.10/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://0.0.0.0/hook",
+		"https://internal.example/hook",
+		"https://missing.example/hook",
+	} {
+		if err := g.AddWebhook(owner.ID, raw, []string{WebhookGameEnd}); err == nil {
+			t.Errorf("AddWebhook(%q) succeeded, want rejected", raw)
+		}
+		if err := g.SetWebhook(owner.ID, raw); err == nil {
+			t.Errorf("SetWebhook(%q) succeeded, want rejected", raw)
+		}
+	}
+	if err := g.AddWebhook(owner.ID, "https://public.example/hook", []string{WebhookGameEnd}); err != nil {
+		t.Errorf("AddWebhook on a public host: %v", err)
+	}
+}
+
+func TestWebhookDialControl_RefusesPrivateAddresses(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34:443":  true,
+		"127.0.0.1:443":      false,
+		"10.1.2.3:443":       false,
+		"169.254.169.254:80": false,
+		"[fe80::1]:443":      false,
+		"[2606:4700::1]:443": true,
+	} {
+		err := webhookDialControl("tcp", addr, nil)
+		if got := err == nil; got != want {
+			t.Errorf("webhookDialControl(%q) allowed = %v, want %v", addr, got, want)
+		}
+	}
+}
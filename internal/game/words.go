@@ -2,9 +2,12 @@ package game
 
 import (
 	"embed"
+	"errors"
 	"io/fs"
 	"math/rand"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,55 +18,217 @@ const minWordLen = 6
 
 // SupportedLanguages returns language codes that have an embedded word list.
 func SupportedLanguages() []string {
-	return []string{"en", "no"}
+	return []string{"en", "no", "fr"}
 }
 
-// loadWords reads the embedded word file for lang and returns words of at least minWordLen.
-func loadWords(lang string) ([]string, error) {
+// WordEntry is one line of a word list. Lines written as "category:word"
+// carry a category; plain lines have none.
+type WordEntry struct {
+	Word     string
+	Category string
+}
+
+// wordList is a parsed word file, or the error reading it.
+type wordList struct {
+	words []WordEntry
+	err   error
+}
+
+// wordLists caches parsed word files by language code.
+var wordLists sync.Map // string -> wordList
+
+// wordListOnce guards the first parse of each supported language. It is
+// filled once and only read afterwards; other codes are never cached, so
+// arbitrary input cannot grow wordLists.
+var wordListOnce = func() map[string]*sync.Once {
+	m := make(map[string]*sync.Once)
+	for _, lang := range SupportedLanguages() {
+		m[lang] = new(sync.Once)
+	}
+	return m
+}()
+
+// loadWords returns the words of at least minWordLen in the embedded word file
+// for lang. Lists are parsed once per language and shared, so callers must not
+// modify the returned slice.
+func loadWords(lang string) ([]WordEntry, error) {
 	name := strings.TrimSpace(lang)
 	if name == "" {
 		name = "en"
 	}
-	name = "words/" + name + ".txt"
-	b, err := fs.ReadFile(wordsFS, name)
+	once, ok := wordListOnce[name]
+	if !ok {
+		return readWords(name)
+	}
+	once.Do(func() {
+		words, err := readWords(name)
+		wordLists.Store(name, wordList{words: words, err: err})
+	})
+	cached, _ := wordLists.Load(name)
+	list := cached.(wordList)
+	return list.words, list.err
+}
+
+// readWords parses the embedded word file for lang.
+func readWords(lang string) ([]WordEntry, error) {
+	b, err := fs.ReadFile(wordsFS, "words/"+lang+".txt")
 	if err != nil {
 		return nil, err
 	}
-	var out []string
-	for _, line := range strings.Split(string(b), "\n") {
-		w := strings.TrimSpace(strings.ToLower(line))
-		if len(w) >= minWordLen {
-			out = append(out, w)
+	return parseWords(string(b)), nil
+}
+
+// parseWords parses word-list text, one entry per line, lowercasing words and
+// categories and dropping words shorter than minWordLen.
+func parseWords(text string) []WordEntry {
+	var out []WordEntry
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.ToLower(line))
+		var category string
+		if c, w, ok := strings.Cut(line, ":"); ok {
+			category, line = strings.TrimSpace(c), strings.TrimSpace(w)
+		}
+		if len(line) >= minWordLen {
+			out = append(out, WordEntry{Word: line, Category: category})
 		}
 	}
-	return out, nil
+	return out
 }
 
+// ErrNotEnoughWords is returned by BuildRoundsUnique when the word list has
+// fewer distinct words than rounds requested.
+var ErrNotEnoughWords = errors.New("not enough distinct words for every round")
+
 // BuildRounds builds count rounds for the given language, shuffling words and letters.
-func BuildRounds(lang string, count int) []Round {
-	if count < 1 {
-		count = 1
+// Words in banned are skipped unless that would leave nothing to pick from.
+// No word repeats until every word has been dealt once.
+func BuildRounds(lang string, count int, banned []string) []Round {
+	return buildRounds(lang, "", count, banned)
+}
+
+// BuildRoundsUnique is BuildRounds but fails with ErrNotEnoughWords rather
+// than repeat a word.
+func BuildRoundsUnique(lang string, count int, banned []string) ([]Round, error) {
+	rounds, reshuffles := pickRounds(wordPool(lang), "", count, banned, newWordRand())
+	if len(rounds) == 0 || reshuffles > 0 {
+		return nil, ErrNotEnoughWords
 	}
+	return rounds, nil
+}
+
+// BuildRoundsWithCategory is BuildRounds restricted to words tagged with
+// category. If the category has fewer than count words, the remaining rounds
+// are drawn from the full list. An empty category uses every word.
+func BuildRoundsWithCategory(lang, category string, count int) []Round {
+	return buildRounds(lang, category, count, nil)
+}
+
+func buildRounds(lang, category string, count int, banned []string) []Round {
+	rounds, _ := pickRounds(wordPool(lang), category, count, banned, newWordRand())
+	return rounds
+}
+
+// wordPool returns the word list for lang, falling back to English.
+func wordPool(lang string) []WordEntry {
 	pool, err := loadWords(lang)
 	if err != nil || len(pool) == 0 {
 		pool, _ = loadWords("en")
 	}
+	return pool
+}
+
+func newWordRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// pickRounds deals count (at least one) rounds from pool, preferring words in
+// category and topping up from the rest of pool when the category runs short.
+// Each distinct word is dealt once before any repeats; when count exceeds them
+// the words are reshuffled and dealt again, and reshuffles reports how often.
+func pickRounds(pool []WordEntry, category string, count int, banned []string, rng *rand.Rand) (rounds []Round, reshuffles int) {
+	if count < 1 {
+		count = 1
+	}
+	if allowed := withoutBanned(pool, banned); len(allowed) > 0 {
+		pool = allowed
+	}
 	if len(pool) == 0 {
-		return nil
+		return nil, 0
 	}
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rng.Shuffle(len(pool), func(i, j int) {
-		pool[i], pool[j] = pool[j], pool[i]
-	})
-	rounds := make([]Round, 0, count)
-	for i := 0; i < count; i++ {
-		word := pool[i%len(pool)]
+	category = strings.ToLower(strings.TrimSpace(category))
+	var picked []WordEntry
+	if category != "" {
+		var inCategory, rest []WordEntry
+		for _, e := range pool {
+			if e.Category == category {
+				inCategory = append(inCategory, e)
+			} else {
+				rest = append(rest, e)
+			}
+		}
+		shuffleEntries(inCategory, rng)
+		shuffleEntries(rest, rng)
+		picked = append(inCategory, rest...)
+	} else {
+		picked = slices.Clone(pool)
+		shuffleEntries(picked, rng)
+	}
+	picked = uniqueWords(picked)
+	rounds = make([]Round, 0, count)
+	for i := 0; len(rounds) < count; i++ {
+		if i == len(picked) {
+			shuffleEntries(picked, rng)
+			if last := len(picked) - 1; picked[0].Word == rounds[len(rounds)-1].Word {
+				// Don't deal the same word twice in a row across the reshuffle.
+				picked[0], picked[last] = picked[last], picked[0]
+			}
+			reshuffles++
+			i = 0
+		}
+		entry := picked[i]
 		rounds = append(rounds, Round{
-			Word:      word,
-			Scrambled: scrambleWord(word, rng),
+			Word:      entry.Word,
+			Scrambled: scrambleWord(entry.Word, rng),
+			Category:  entry.Category,
 		})
 	}
-	return rounds
+	return rounds, reshuffles
+}
+
+// uniqueWords drops entries whose word appeared earlier in entries, in place.
+func uniqueWords(entries []WordEntry) []WordEntry {
+	seen := make(map[string]bool, len(entries))
+	return slices.DeleteFunc(entries, func(e WordEntry) bool {
+		if seen[e.Word] {
+			return true
+		}
+		seen[e.Word] = true
+		return false
+	})
+}
+
+func shuffleEntries(entries []WordEntry, rng *rand.Rand) {
+	rng.Shuffle(len(entries), func(i, j int) {
+		entries[i], entries[j] = entries[j], entries[i]
+	})
+}
+
+// withoutBanned returns the entries in pool whose word is not in banned.
+func withoutBanned(pool []WordEntry, banned []string) []WordEntry {
+	if len(banned) == 0 {
+		return pool
+	}
+	skip := make(map[string]bool, len(banned))
+	for _, w := range banned {
+		skip[w] = true
+	}
+	out := make([]WordEntry, 0, len(pool))
+	for _, e := range pool {
+		if !skip[e.Word] {
+			out = append(out, e)
+		}
+	}
+	return out
 }
 
 func scrambleWord(word string, rng *rand.Rand) string {
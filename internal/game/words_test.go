@@ -0,0 +1,244 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuildRounds_French(t *testing.T) {
+	words, err := loadWords("fr")
+	if err != nil {
+		t.Fatalf("loadWords(fr): %v", err)
+	}
+	if len(words) < 500 {
+		t.Fatalf("French list has %d words, want at least 500", len(words))
+	}
+	known := make(map[string]bool, len(words))
+	for _, w := range words {
+		known[w.Word] = true
+	}
+	for _, round := range BuildRounds("fr", 5, nil) {
+		if !known[round.Word] {
+			t.Errorf("round word %q is not from the French list", round.Word)
+		}
+		a, b := strings.Split(round.Word, ""), strings.Split(round.Scrambled, "")
+		sort.Strings(a)
+		sort.Strings(b)
+		if strings.Join(a, "") != strings.Join(b, "") {
+			t.Errorf("scrambled %q is not an anagram of %q", round.Scrambled, round.Word)
+		}
+	}
+}
+
+const testWordList = `
+animals:giraffe
+animals:penguin
+Animals : dolphin
+food:noodles
+plainword
+anotherword
+cat:tiny
+`
+
+func TestParseWords(t *testing.T) {
+	got := parseWords(testWordList)
+	want := []WordEntry{
+		{"giraffe", "animals"},
+		{"penguin", "animals"},
+		{"dolphin", "animals"},
+		{"noodles", "food"},
+		{"plainword", ""},
+		{"anotherword", ""},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseWords = %v, want %v", got, want)
+	}
+}
+
+func TestPickRounds_Category(t *testing.T) {
+	pool := parseWords(testWordList)
+	tests := []struct {
+		name         string
+		category     string
+		count        int
+		banned       []string
+		wantCategory int // leading rounds that must come from category
+	}{
+		{"filters to category", "animals", 3, nil, 3},
+		{"category matched case-insensitively", " Animals ", 2, nil, 2},
+		{"short category falls back to full pool", "food", 4, nil, 1},
+		{"banned words leave the category", "animals", 3, []string{"giraffe"}, 2},
+		{"unknown category uses full pool", "planets", 3, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			rounds, _ := pickRounds(pool, tt.category, tt.count, tt.banned, rng)
+			if len(rounds) != tt.count {
+				t.Fatalf("got %d rounds, want %d", len(rounds), tt.count)
+			}
+			seen := map[string]bool{}
+			category := strings.ToLower(strings.TrimSpace(tt.category))
+			for i, r := range rounds {
+				if slices.Contains(tt.banned, r.Word) {
+					t.Errorf("round %d: banned word %q dealt", i, r.Word)
+				}
+				if seen[r.Word] {
+					t.Errorf("round %d: %q repeated while unused words remain", i, r.Word)
+				}
+				seen[r.Word] = true
+				inCategory := r.Category == category
+				if i < tt.wantCategory && !inCategory {
+					t.Errorf("round %d: %q (%q) not from category %q", i, r.Word, r.Category, category)
+				}
+				if i >= tt.wantCategory && inCategory {
+					t.Errorf("round %d: %q from category after it should be exhausted", i, r.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestPickRounds_Unique(t *testing.T) {
+	// "giraffe" is listed twice, under two categories.
+	pool := append(parseWords(testWordList), WordEntry{Word: "giraffe", Category: "tall"})
+	distinct := 6
+	tests := []struct {
+		name           string
+		count          int
+		wantReshuffles int
+	}{
+		{"fewer than the list", 4, 0},
+		{"exactly the list", distinct, 0},
+		{"one more than the list", distinct + 1, 1},
+		{"three passes", 3 * distinct, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds, reshuffles := pickRounds(pool, "", tt.count, nil, rand.New(rand.NewSource(1)))
+			if len(rounds) != tt.count {
+				t.Fatalf("got %d rounds, want %d", len(rounds), tt.count)
+			}
+			if reshuffles != tt.wantReshuffles {
+				t.Errorf("reshuffles = %d, want %d", reshuffles, tt.wantReshuffles)
+			}
+			for start := 0; start < len(rounds); start += distinct {
+				pass := rounds[start:min(start+distinct, len(rounds))]
+				seen := map[string]bool{}
+				for _, r := range pass {
+					if seen[r.Word] {
+						t.Errorf("%q dealt twice in one pass of the list", r.Word)
+					}
+					seen[r.Word] = true
+				}
+			}
+			for i := 1; i < len(rounds); i++ {
+				if rounds[i].Word == rounds[i-1].Word {
+					t.Errorf("rounds %d and %d both deal %q", i-1, i, rounds[i].Word)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildRoundsUnique(t *testing.T) {
+	pool, _ := loadWords("en")
+	distinct := len(uniqueWords(slices.Clone(pool)))
+
+	rounds, err := BuildRoundsUnique("en", distinct, nil)
+	if err != nil {
+		t.Fatalf("BuildRoundsUnique(%d): %v", distinct, err)
+	}
+	if len(rounds) != distinct {
+		t.Errorf("got %d rounds, want %d", len(rounds), distinct)
+	}
+	if _, err := BuildRoundsUnique("en", distinct+1, nil); !errors.Is(err, ErrNotEnoughWords) {
+		t.Errorf("BuildRoundsUnique(%d): err = %v, want ErrNotEnoughWords", distinct+1, err)
+	}
+}
+
+func TestGame_AddRound_AvoidsUsedWords(t *testing.T) {
+	g := NewGame(1, time.Minute, "en")
+	owner := g.AddPlayer("alice")
+	for len(g.RoundData) < MaxRounds {
+		if err := g.AddRound(owner.ID); err != nil {
+			t.Fatalf("AddRound: %v", err)
+		}
+	}
+	seen := map[string]bool{}
+	for _, r := range g.RoundData {
+		if seen[r.Word] {
+			t.Errorf("%q dealt twice in one game", r.Word)
+		}
+		seen[r.Word] = true
+	}
+}
+
+func FuzzBuildRounds(f *testing.F) {
+	pool, _ := loadWords("en")
+	distinct := len(uniqueWords(slices.Clone(pool)))
+	for _, count := range []int{-1, 0, 1, 5, distinct, distinct + 3} {
+		f.Add(count)
+	}
+	f.Fuzz(func(t *testing.T, count int) {
+		count %= 3 * distinct // keep each run cheap
+		rounds := BuildRounds("en", count, nil)
+		if want := max(count, 1); len(rounds) != want {
+			t.Fatalf("BuildRounds(%d) dealt %d rounds, want %d", count, len(rounds), want)
+		}
+		seen := map[string]bool{}
+		for i, r := range rounds[:min(len(rounds), distinct)] {
+			if seen[r.Word] {
+				t.Fatalf("BuildRounds(%d): round %d repeats %q before the list ran out", count, i, r.Word)
+			}
+			seen[r.Word] = true
+		}
+	})
+}
+
+func TestStore_CreateGameWithCategory(t *testing.T) {
+	s := NewStore()
+	g := s.CreateGameWithCategory(2, time.Minute, "en", " Planets ")
+	t.Cleanup(func() { s.DeleteGame(g.ID) })
+	if g.Category != "planets" {
+		t.Errorf("Category = %q, want normalised %q", g.Category, "planets")
+	}
+	if len(g.RoundData) != 2 {
+		t.Errorf("got %d rounds, want 2 drawn from the full list", len(g.RoundData))
+	}
+}
+
+func TestLoadWords_Cached(t *testing.T) {
+	var wg sync.WaitGroup
+	lists := make([][]WordEntry, 2)
+	for i := range lists {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lists[i], _ = loadWords("no")
+		}()
+	}
+	wg.Wait()
+	if len(lists[0]) == 0 || len(lists[1]) == 0 {
+		t.Fatal("loadWords(no) returned no words")
+	}
+	if &lists[0][0] != &lists[1][0] {
+		t.Error("concurrent loadWords calls parsed the list twice")
+	}
+	if _, err := loadWords("xx"); err == nil {
+		t.Error("loadWords(xx) succeeded for a language with no word list")
+	}
+}
+
+func BenchmarkBuildRounds(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BuildRounds("en", 5, nil)
+	}
+}
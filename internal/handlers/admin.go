@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dagame/internal/game"
+)
+
+// adminStats reports store-wide counts. Each server only holds its own store,
+// so the response carries the "unscrambler" section of the combined shape.
+func (h *GameHandler) adminStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"unscrambler": h.store.Stats()})
+}
+
+// adminGames lists the games that have not finished, oldest first.
+func (h *GameHandler) adminGames(w http.ResponseWriter, r *http.Request) {
+	games := h.store.ListActiveGames()
+	if games == nil {
+		games = []game.GameSummary{}
+	}
+	writeJSON(w, map[string]any{"games": games})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/internal/game"
+)
+
+func TestAdminStats_RequiresSecret(t *testing.T) {
+	t.Setenv("ADMIN_SECRET", "s3cret")
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	g.AddPlayer("alice")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("no token: status %d, want 401", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("with token: status %d, want 200", rec.Code)
+	}
+	var body struct{ Unscrambler game.StoreStats }
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Unscrambler.TotalGames != 1 || body.Unscrambler.TotalPlayers != 1 || body.Unscrambler.GamesByStatus[game.StatusLobby] != 1 {
+		t.Errorf("stats %+v", body.Unscrambler)
+	}
+}
+
+func TestAdminGames(t *testing.T) {
+	store := game.NewStore()
+	older := store.CreateGame(1, time.Minute, "en")
+	newer := store.CreateGame(1, time.Minute, "en")
+	finished := store.CreateGame(1, time.Minute, "en")
+	for _, g := range []*game.Game{older, newer, finished} {
+		t.Cleanup(func() { store.DeleteGame(g.ID) })
+	}
+	older.CreatedAt = newer.CreatedAt.Add(-time.Hour)
+	older.AddPlayer("alice")
+	older.AddPlayer("bob")
+	finished.Status = game.StatusFinished
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/games", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		env      string
+		token    string
+		wantCode int
+	}{
+		{"no secret hides the route", "", "", "", http.StatusNotFound},
+		{"no secret in development", "", "development", "", http.StatusOK},
+		{"missing token", "s3cret", "", "", http.StatusUnauthorized},
+		{"wrong token", "s3cret", "", "nope", http.StatusUnauthorized},
+		{"wrong token in development", "s3cret", "development", "nope", http.StatusUnauthorized},
+		{"correct token", "s3cret", "", "s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_SECRET", tt.secret)
+			t.Setenv("ENV", tt.env)
+			if rec := get(tt.token); rec.Code != tt.wantCode {
+				t.Errorf("status %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+
+	t.Setenv("ADMIN_SECRET", "s3cret")
+	rec := get("s3cret")
+	var body struct{ Games []game.GameSummary }
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Games) != 2 {
+		t.Fatalf("games %+v, want the two unfinished ones", body.Games)
+	}
+	if body.Games[0].ID != older.ID || body.Games[1].ID != newer.ID {
+		t.Errorf("order %q, %q; want oldest first", body.Games[0].ID, body.Games[1].ID)
+	}
+	if got := body.Games[0]; got.PlayerCount != 2 || got.Status != game.StatusLobby || got.CurrentRound != 0 {
+		t.Errorf("summary %+v", got)
+	}
+}
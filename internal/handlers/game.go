@@ -3,44 +3,92 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/a-h/templ"
 	"github.com/go-chi/chi/v5"
 
+	"dagame/internal/admin"
 	"dagame/internal/game"
 	"dagame/internal/viewmodel"
+	"dagame/pkg/ratelimit"
+	"dagame/pkg/realtime"
 	"dagame/views/components"
 	"dagame/views/pages"
 )
 
 type GameHandler struct {
 	store *game.Store
+
+	guessLimits *ratelimit.Keyed // game ID -> player ID -> bucket
 }
 
 // NewGameHandler builds the handler for game session routes.
 func NewGameHandler(store *game.Store) *GameHandler {
-	return &GameHandler{store: store}
+	h := &GameHandler{
+		store:       store,
+		guessLimits: ratelimit.NewKeyed(ratelimit.GuessesPerSecond, ratelimit.GuessBurst),
+	}
+	// Each round starts with full buckets, and deleted games drop theirs.
+	store.OnRoundAdvance(h.guessLimits.Clear)
+	store.OnGameDeleted(h.guessLimits.Clear)
+	return h
 }
 
 // RegisterRoutes wires game session endpoints.
 func (h *GameHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(admin.Require)
+		r.Get("/admin/stats", h.adminStats)
+		r.Get("/admin/games", h.adminGames)
+	})
+	r.Get("/overlay/game/{id}", h.overlay)
 	r.Route("/game/{id}", func(r chi.Router) {
+		r.Use(h.touchPlayer)
 		r.Get("/", h.gamePage)
 		r.Post("/join", h.joinGame)
 		r.Post("/start", h.startGame)
+		r.Post("/ready", h.readyUp)
+		r.Post("/rounds/add", h.addRound)
+		r.Post("/rounds/remove", h.removeLastRound)
+		r.Post("/ban", h.banWord)
+		r.Post("/webhook", h.setWebhook)
+		r.Post("/webhooks", h.addWebhook)
+		r.Post("/bonus", h.awardBonus)
+		r.Post("/mute", h.mutePlayer)
+		r.Post("/unmute", h.unmutePlayer)
+		r.Post("/transfer", h.transferOwnership)
+		r.Delete("/player/{handle}", h.kickPlayer)
 		r.Post("/restart", h.restartGame)
+		r.Post("/extend", h.extendRound)
 		r.Get("/round", h.roundFragment)
 		r.Get("/players", h.playersFragment)
 		r.Get("/scores", h.scoresFragment)
 		r.Get("/stream", h.stream)
+		r.Get("/ws", h.websocket)
 		r.Post("/progress", h.progressUpdate)
 		r.Post("/guess", h.submitGuess)
+		r.Get("/hint", h.requestHint)
+		r.Get("/state.json", h.stateJSON)
+		r.Get("/stats", h.gameStats)
+		r.Get("/history", h.roundHistory)
+	})
+}
+
+// touchPlayer marks the requesting player as active before any game route
+// runs, so closed tabs can be told apart from quiet players.
+func (h *GameHandler) touchPlayer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gameID := chi.URLParam(r, "id")
+		h.store.TouchPlayer(gameID, PlayerIDFromCookie(r, gameID))
+		next.ServeHTTP(w, r)
 	})
 }
 
@@ -53,25 +101,33 @@ func (h *GameHandler) gamePage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	playerName, hasPlayer := h.findPlayerName(r, instance)
-	playerID := playerIDFromCookie(r, gameID)
+	playerID := PlayerIDFromCookie(r, gameID)
 	isOwner := instance.IsOwner(playerID)
 	inviteURL := buildInviteURL(r, gameID)
 	snapshot := instance.Snapshot(time.Now().UTC())
-	showStart := hasPlayer && isOwner && snapshot.Status == game.StatusLobby
+	isSpectator := instance.IsSpectator(playerID)
+	showReady := hasPlayer && snapshot.Status == game.StatusLobby && !instance.IsReady(playerID) && !isSpectator
 	duration := int(snapshot.RoundDuration.Seconds())
 
 	data := viewmodel.GamePage{
 		Title:          "Dagame",
+		Lang:           snapshot.Lang,
 		GameID:         gameID,
 		InviteURL:      inviteURL,
 		Players:        toPlayerProgress(snapshot.Progress, ""),
+		PlayerCount:    snapshot.PlayerCount(),
+		SpectatorCount: snapshot.SpectatorCount,
 		HasPlayer:      hasPlayer,
 		PlayerName:     playerName,
 		IsOwner:        isOwner,
+		IsSpectator:    isSpectator,
+		IsProtected:    snapshot.IsProtected,
+		CanStart:       snapshot.HasMinimumPlayers(),
+		Muted:          instance.IsMuted(playerID),
 		Rounds:         snapshot.Rounds,
 		RoundDuration:  duration,
 		Status:         snapshot.Status,
-		ShowStart:      showStart,
+		ShowReady:      showReady,
 		Scores:         toScoreEntries(snapshot.Scores),
 		WinnerName:     snapshot.WinnerName,
 		CurrentRound:   snapshot.CurrentRound,
@@ -80,10 +136,159 @@ func (h *GameHandler) gamePage(w http.ResponseWriter, r *http.Request) {
 		Scrambled:      snapshot.RoundData.Scrambled,
 		TargetWord:     snapshot.RoundData.Word,
 		WordLength:     snapshot.WordLength,
+
+		EstimatedRemainingMin: (snapshot.EstimatedRemainingSec + 59) / 60,
 	}
 	render(w, r, pages.GamePage(data))
 }
 
+// gameStateJSON is the public, machine-readable view of a game. It never
+// includes the unsolved word or player IDs, which double as session cookies.
+type gameStateJSON struct {
+	ID              string      `json:"id"`
+	Lang            string      `json:"lang"`
+	Status          string      `json:"status"`
+	CurrentRound    int         `json:"current_round"`
+	Rounds          int         `json:"rounds"`
+	Scrambled       string      `json:"scrambled,omitempty"`
+	RevealedWord    string      `json:"revealed_word,omitempty"`
+	WordLength      int         `json:"word_length"`
+	TimeRemainingMs int64       `json:"time_remaining_ms"`
+	RoundWinner     string      `json:"round_winner,omitempty"`
+	RoundWinners    []string    `json:"round_winners,omitempty"`
+	WinnerName      string      `json:"winner,omitempty"`
+	Players         []string    `json:"players"`
+	Scores          []scoreJSON `json:"scores"`
+}
+
+type scoreJSON struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+// stateJSON serves the current game state for assistive tech, bots and tests.
+func (h *GameHandler) stateJSON(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	now := time.Now().UTC()
+	snapshot := instance.Snapshot(now)
+	state := gameStateJSON{
+		ID:           snapshot.ID,
+		Lang:         snapshot.Lang,
+		Status:       snapshot.Status,
+		CurrentRound: snapshot.CurrentRound,
+		Rounds:       snapshot.Rounds,
+		RevealedWord: snapshot.RevealedWord,
+		WordLength:   snapshot.WordLength,
+		RoundWinner:  snapshot.RoundWinner,
+		RoundWinners: snapshot.RoundWinners,
+		WinnerName:   snapshot.WinnerName,
+		Players:      make([]string, len(snapshot.Players)),
+		Scores:       make([]scoreJSON, len(snapshot.Scores)),
+	}
+	if instance.IsActive() {
+		state.Scrambled = snapshot.RoundData.Scrambled
+		if snapshot.RoundEndedAt.IsZero() {
+			if remaining := snapshot.RoundStarted.Add(snapshot.RoundDuration).Sub(now); remaining > 0 {
+				state.TimeRemainingMs = remaining.Milliseconds()
+			}
+		}
+	}
+	for i, player := range snapshot.Players {
+		state.Players[i] = player.Name
+	}
+	for i, entry := range snapshot.Scores {
+		state.Scores[i] = scoreJSON{Name: entry.Name, Points: entry.Points}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, state)
+}
+
+// gameStats reports player and open stream counts for debugging stuck
+// connections.
+func (h *GameHandler) gameStats(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snapshot := instance.Snapshot(time.Now().UTC())
+	writeJSON(w, gameStatsJSON{
+		Players:     snapshot.PlayerCount(),
+		Connections: h.store.ActiveConnectionCount(gameID),
+		Status:      snapshot.Status,
+	})
+}
+
+type gameStatsJSON struct {
+	Players     int    `json:"players"`
+	Connections int    `json:"connections"`
+	Status      string `json:"status"`
+}
+
+// roundHistory serves the results of every completed round as JSON.
+func (h *GameHandler) roundHistory(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snapshot := instance.Snapshot(time.Now().UTC())
+	history := make([]roundResultJSON, len(snapshot.RoundHistory))
+	for i, result := range snapshot.RoundHistory {
+		history[i] = roundResultJSON{
+			Round:        result.Round,
+			Word:         result.Word,
+			Scrambled:    result.Scrambled,
+			Winner:       result.Winner,
+			WinnerPoints: result.WinnerPoints,
+			TimedOut:     result.TimedOut,
+		}
+		if !result.SolvedAt.IsZero() {
+			solvedAt := result.SolvedAt
+			history[i].SolvedAt = &solvedAt
+		}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, history)
+}
+
+type roundResultJSON struct {
+	Round        int        `json:"round"`
+	Word         string     `json:"word"`
+	Scrambled    string     `json:"scrambled"`
+	Winner       string     `json:"winner,omitempty"`
+	WinnerPoints int        `json:"winner_points"`
+	SolvedAt     *time.Time `json:"solved_at,omitempty"`
+	TimedOut     bool       `json:"timed_out"`
+}
+
+// overlay renders the read-only OBS overlay; no player cookie is needed.
+func (h *GameHandler) overlay(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	snapshot := instance.Snapshot(time.Now().UTC())
+	render(w, r, pages.OverlayPage(viewmodel.OverlayPage{
+		Lang:         snapshot.Lang,
+		Status:       snapshot.Status,
+		CurrentRound: snapshot.CurrentRound,
+		TotalRounds:  snapshot.Rounds,
+		Scrambled:    snapshot.RoundData.Scrambled,
+		Scores:       toScoreEntries(snapshot.Scores),
+		WinnerName:   snapshot.WinnerName,
+	}))
+}
+
 func (h *GameHandler) joinGame(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	instance, ok := h.store.GetGame(gameID)
@@ -103,10 +308,16 @@ func (h *GameHandler) joinGame(w http.ResponseWriter, r *http.Request) {
 	if len(username) > 20 {
 		username = username[:20]
 	}
+	if !instance.CheckPassword(r.FormValue("password")) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		render(w, r, pages.JoinError(gameID, "Wrong password."))
+		return
+	}
 
-	player := instance.AddPlayer(username)
+	player := instance.AddPlayerAs(username, r.FormValue("role"))
 
-	setPlayerCookie(w, gameID, player.ID)
+	SetPlayerCookie(w, gameID, player.ID)
 	h.store.Publish(gameID, "players")
 	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 }
@@ -118,12 +329,13 @@ func (h *GameHandler) startGame(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	playerID := playerIDFromCookie(r, gameID)
-	if !instance.IsOwner(playerID) {
+	playerID := PlayerIDFromCookie(r, gameID)
+	now := time.Now().UTC()
+	if !instance.IsOwner(playerID) || !instance.Snapshot(now).HasMinimumPlayers() {
 		http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 		return
 	}
-	_ = instance.Start(time.Now().UTC())
+	_ = instance.Start(now)
 	h.store.EnsureRoundLoop(gameID, instance)
 	h.store.Publish(gameID, "round")
 	h.store.Publish(gameID, "scores")
@@ -131,6 +343,300 @@ func (h *GameHandler) startGame(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 }
 
+// readyUp marks the current player ready; the last ready player starts the game.
+func (h *GameHandler) readyUp(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := PlayerIDFromCookie(r, gameID)
+	if playerID == "" {
+		http.Error(w, "not a player", http.StatusForbidden)
+		return
+	}
+	err := instance.ReadyUp(playerID, time.Now().UTC())
+	if err != nil && !errors.Is(err, game.ErrAutoStarted) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	// The unscrambler lobby lives in the players panel, so "players" is its lobby event.
+	h.store.Publish(gameID, "players")
+	if errors.Is(err, game.ErrAutoStarted) {
+		h.store.EnsureRoundLoop(gameID, instance)
+		h.store.Publish(gameID, "round")
+		h.store.Publish(gameID, "scores")
+	}
+	// Like the explain game, reply 204: the form stays put and SSE refreshes the lobby.
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GameHandler) addRound(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := instance.AddRound(PlayerIDFromCookie(r, gameID)); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "round")
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+func (h *GameHandler) removeLastRound(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := instance.RemoveLastRound(PlayerIDFromCookie(r, gameID)); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "round")
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+func (h *GameHandler) banWord(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	if err := instance.BanWord(PlayerIDFromCookie(r, gameID), r.FormValue("word")); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+func (h *GameHandler) awardBonus(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	points := parseInt(r.FormValue("points"), 0)
+	targetID, ok := instance.PlayerIDByHandle(r.FormValue("player"))
+	if !ok {
+		http.Error(w, "player not found", http.StatusConflict)
+		return
+	}
+	if err := instance.AwardBonus(PlayerIDFromCookie(r, gameID), targetID, points); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "scores")
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+func (h *GameHandler) mutePlayer(w http.ResponseWriter, r *http.Request) {
+	h.setMuted(w, r, (*game.Game).MutePlayer)
+}
+
+func (h *GameHandler) unmutePlayer(w http.ResponseWriter, r *http.Request) {
+	h.setMuted(w, r, (*game.Game).UnmutePlayer)
+}
+
+func (h *GameHandler) setMuted(w http.ResponseWriter, r *http.Request, apply func(g *game.Game, ownerID, targetID string) error) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	ownerID := PlayerIDFromCookie(r, gameID)
+	if !instance.IsOwner(ownerID) {
+		http.Error(w, game.ErrNotOwner.Error(), http.StatusForbidden)
+		return
+	}
+	targetID, ok := instance.PlayerIDByHandle(r.FormValue("player"))
+	if !ok {
+		http.Error(w, "player not found", http.StatusConflict)
+		return
+	}
+	if err := apply(instance, ownerID, targetID); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "players")
+	h.store.Publish(gameID, "round")
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+// transferOwnership hands the game to the player whose handle is in the
+// newOwner form field.
+func (h *GameHandler) transferOwnership(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	newOwnerID, ok := instance.PlayerIDByHandle(r.FormValue("newOwner"))
+	if !ok {
+		http.Error(w, "player not found", http.StatusConflict)
+		return
+	}
+	err := instance.TransferOwnership(PlayerIDFromCookie(r, gameID), newOwnerID)
+	if errors.Is(err, game.ErrNotOwner) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	// The round panel carries the lobby controls and the scores panel the
+	// owner's forms, so both re-render for the old and new owner.
+	h.store.Publish(gameID, "players")
+	h.store.Publish(gameID, "round")
+	h.store.Publish(gameID, "scores")
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+// maxExtendSeconds caps a single round extension.
+const maxExtendSeconds = 30
+
+// extendRound adds up to maxExtendSeconds to the running round; owner only.
+func (h *GameHandler) extendRound(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	ownerID := PlayerIDFromCookie(r, gameID)
+	if !instance.IsOwner(ownerID) {
+		http.Error(w, "only the owner can extend the round", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	seconds := min(parseInt(r.FormValue("seconds"), 15), maxExtendSeconds)
+	if err := instance.ExtendRound(ownerID, time.Duration(seconds)*time.Second, time.Now().UTC()); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.WakeRoundLoop(gameID)
+	h.store.Publish(gameID, "round")
+	if r.Header.Get("Hx-Request") == "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
+}
+
+// kickPlayer removes the player with the handle in the URL; owner only.
+func (h *GameHandler) kickPlayer(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	ownerID := PlayerIDFromCookie(r, gameID)
+	if !instance.IsOwner(ownerID) {
+		http.Error(w, "only the owner can kick players", http.StatusForbidden)
+		return
+	}
+	targetID, ok := instance.PlayerIDByHandle(chi.URLParam(r, "handle"))
+	if !ok {
+		http.Error(w, "player not found", http.StatusConflict)
+		return
+	}
+	if err := instance.KickPlayer(ownerID, targetID); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "players")
+	h.store.Publish(gameID, "scores")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setWebhook sets the Discord results webhook from a JSON body {"url"}.
+func (h *GameHandler) setWebhook(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	playerID := PlayerIDFromCookie(r, gameID)
+	if !instance.IsOwner(playerID) {
+		http.Error(w, "not the owner", http.StatusForbidden)
+		return
+	}
+	if err := instance.SetWebhook(playerID, body.URL); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addWebhook adds an event webhook from the form fields url and events
+// (comma-separated).
+func (h *GameHandler) addWebhook(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	playerID := PlayerIDFromCookie(r, gameID)
+	if !instance.IsOwner(playerID) {
+		http.Error(w, "not the owner", http.StatusForbidden)
+		return
+	}
+	var events []string
+	for _, event := range strings.Split(r.FormValue("events"), ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	if err := instance.AddWebhook(playerID, r.FormValue("url"), events); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *GameHandler) restartGame(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	instance, ok := h.store.GetGame(gameID)
@@ -138,12 +644,13 @@ func (h *GameHandler) restartGame(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	playerID := playerIDFromCookie(r, gameID)
+	playerID := PlayerIDFromCookie(r, gameID)
 	if !instance.IsOwner(playerID) {
 		http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 		return
 	}
-	instance.Restart(time.Now().UTC())
+	opts := game.RestartOptions{RebuildWords: r.FormValue("same_words") != "true"}
+	instance.RestartWithOptions(time.Now().UTC(), opts)
 	h.store.EnsureRoundLoop(gameID, instance)
 	h.store.Publish(gameID, "round")
 	h.store.Publish(gameID, "scores")
@@ -162,6 +669,11 @@ func (h *GameHandler) roundFragment(w http.ResponseWriter, r *http.Request) {
 	now := time.Now().UTC()
 	snapshot := instance.Snapshot(now)
 	data := buildRoundFragment(gameID, snapshot)
+	data.IsOwner = instance.IsOwner(PlayerIDFromCookie(r, gameID))
+	data.Muted = instance.IsMuted(PlayerIDFromCookie(r, gameID))
+	data.Spectating = instance.IsSpectator(PlayerIDFromCookie(r, gameID))
+	data.Solved = instance.HasSolved(PlayerIDFromCookie(r, gameID))
+	data.RoundLocked = data.RoundLocked || data.Solved
 
 	render(w, r, components.RoundFragment(data))
 }
@@ -181,8 +693,10 @@ func (h *GameHandler) scoresFragment(w http.ResponseWriter, r *http.Request) {
 		Scores:     toScoreEntries(snapshot.Scores),
 		WinnerName: snapshot.WinnerName,
 		Status:     snapshot.Status,
-		IsOwner:    instance.IsOwner(playerIDFromCookie(r, gameID)),
+		IsOwner:    instance.IsOwner(PlayerIDFromCookie(r, gameID)),
 		PlayerName: playerName,
+
+		PlayerHandle: instance.PlayerHandle(PlayerIDFromCookie(r, gameID)),
 	}
 	render(w, r, components.ScoresFragment(data))
 }
@@ -201,6 +715,10 @@ func (h *GameHandler) playersFragment(w http.ResponseWriter, r *http.Request) {
 		Players:    toPlayerProgress(snapshot.Progress, playerName),
 		WordLength: snapshot.WordLength,
 		PlayerName: playerName,
+		InLobby:    snapshot.Status == game.StatusLobby,
+
+		PlayerCount:    snapshot.PlayerCount(),
+		SpectatorCount: snapshot.SpectatorCount,
 	}
 	render(w, r, components.PlayersFragment(data))
 }
@@ -212,24 +730,34 @@ func (h *GameHandler) submitGuess(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	playerID := playerIDFromCookie(r, gameID)
-	if playerID == "" {
+	playerID := PlayerIDFromCookie(r, gameID)
+	if _, isPlayer := instance.PlayerName(playerID); !isPlayer {
 		http.Redirect(w, r, "/game/"+gameID, http.StatusSeeOther)
 		return
 	}
+	if ok, wait := h.guessLimits.Allow(gameID, playerID, time.Now()); !ok {
+		ratelimit.TooManyRequests(w, wait, "too many guesses")
+		return
+	}
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "invalid form", http.StatusBadRequest)
 		return
 	}
 	guess := r.FormValue("guess")
-	debugSnapshot := instance.Snapshot(time.Now().UTC())
-	log.Printf("submit guess debug game=%s roundWord=%q scrambled=%q", gameID, debugSnapshot.RoundData.Word, debugSnapshot.RoundData.Scrambled)
+	h.store.Metrics().GuessSubmitted()
 	ok, err := instance.SubmitGuess(playerID, guess, time.Now().UTC())
+	if errors.Is(err, game.ErrPlayerMuted) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	// Player IDs double as session cookies, so logs name the public handle.
+	handle := instance.PlayerHandle(playerID)
 	if err != nil {
-		log.Printf("submit guess error game=%s player=%s err=%v", gameID, playerID, err)
+		slog.Warn("submit guess", slog.String("gameID", gameID), slog.String("player", handle), slog.Any("err", err))
 	}
-	log.Printf("submit guess game=%s player=%s guess=%q ok=%t", gameID, playerID, guess, ok)
+	slog.Info("submit guess", slog.String("gameID", gameID), slog.String("player", handle), slog.String("guess", guess), slog.Bool("ok", ok))
 	if ok {
+		h.guessLimits.Clear(gameID)
 		h.store.WakeRoundLoop(gameID)
 		h.store.Publish(gameID, "round")
 		h.store.Publish(gameID, "scores")
@@ -249,7 +777,7 @@ func (h *GameHandler) progressUpdate(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	playerID := playerIDFromCookie(r, gameID)
+	playerID := PlayerIDFromCookie(r, gameID)
 	if playerID == "" {
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -269,6 +797,40 @@ func (h *GameHandler) progressUpdate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *GameHandler) requestHint(w http.ResponseWriter, r *http.Request) {
+	gameID := chi.URLParam(r, "id")
+	instance, ok := h.store.GetGame(gameID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	playerID := PlayerIDFromCookie(r, gameID)
+	if playerID == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	revealed, err := instance.RequestHint(playerID, time.Now().UTC())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.Publish(gameID, "players")
+	h.store.Publish(gameID, "scores")
+	writeJSON(w, map[string]any{
+		"revealed": revealed,
+	})
+}
+
+// websocket serves the stream over WebSocket only, for clients behind proxies
+// that buffer SSE. It shares stream's subscription and rendering.
+func (h *GameHandler) websocket(w http.ResponseWriter, r *http.Request) {
+	if !realtime.IsWebSocketUpgrade(r) {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return
+	}
+	h.stream(w, r)
+}
+
 func (h *GameHandler) stream(w http.ResponseWriter, r *http.Request) {
 	gameID := chi.URLParam(r, "id")
 	instance, ok := h.store.GetGame(gameID)
@@ -276,37 +838,51 @@ func (h *GameHandler) stream(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	flusher, ok := w.(http.Flusher)
+	// Event stream with explicit event names for round/players/scores.
+	out, ok := realtime.OpenEventSink(w, r)
 	if !ok {
-		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
 		return
 	}
+	defer out.Close()
 
-	// SSE stream with explicit event names for round/players/scores.
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
-
-	playerID := playerIDFromCookie(r, gameID)
+	playerID := PlayerIDFromCookie(r, gameID)
 	playerName, _ := h.findPlayerName(r, instance)
 
 	hub := h.store.Broadcaster(gameID)
 	sub := hub.Subscribe()
 	defer hub.Unsubscribe(sub)
 
+	// Skip re-sending fragments this stream already has.
+	var differ realtime.SnapshotDiffer
+	sendChanged := func(event, html string) {
+		if differ.Changed(event, html) {
+			out.Send(event, html)
+		}
+	}
+
 	sendSnapshot := func(includeRound bool, includePlayers bool, includeScores bool) {
 		snapshot := instance.Snapshot(time.Now().UTC())
 		if includeRound {
-			roundHTML := renderToString(r, components.RoundFragment(buildRoundFragment(gameID, snapshot)))
-			writeSSE(w, "round", roundHTML)
+			roundData := buildRoundFragment(gameID, snapshot)
+			roundData.IsOwner = instance.IsOwner(playerID)
+			roundData.Muted = instance.IsMuted(playerID)
+			roundData.Spectating = instance.IsSpectator(playerID)
+			roundData.Solved = instance.HasSolved(playerID)
+			roundData.RoundLocked = roundData.RoundLocked || roundData.Solved
+			roundHTML := renderToString(r, components.RoundFragment(roundData))
+			sendChanged("round", roundHTML)
 		}
 		if includePlayers {
 			playersHTML := renderToString(r, components.PlayersFragment(viewmodel.PlayersFragment{
 				Players:    toPlayerProgress(snapshot.Progress, playerName),
 				WordLength: snapshot.WordLength,
 				PlayerName: playerName,
+				InLobby:    snapshot.Status == game.StatusLobby,
+
+				PlayerCount:    snapshot.PlayerCount(),
+				SpectatorCount: snapshot.SpectatorCount,
 			}))
-			writeSSE(w, "players", playersHTML)
+			sendChanged("players", playersHTML)
 		}
 		if includeScores {
 			scoresHTML := renderToString(r, components.ScoresFragment(viewmodel.ScoresFragment{
@@ -316,47 +892,75 @@ func (h *GameHandler) stream(w http.ResponseWriter, r *http.Request) {
 				Status:     snapshot.Status,
 				IsOwner:    instance.IsOwner(playerID),
 				PlayerName: playerName,
+
+				PlayerHandle: instance.PlayerHandle(playerID),
 			}))
-			writeSSE(w, "scores", scoresHTML)
+			sendChanged("scores", scoresHTML)
 		}
-		flusher.Flush()
+		out.Flush()
 	}
 
-	sendSnapshot(true, true, true)
+	sendEvent := func(event string) {
+		switch event {
+		case "players":
+			sendSnapshot(false, true, false)
+		case "scores":
+			sendSnapshot(false, false, true)
+		case "round":
+			sendSnapshot(true, false, false)
+		}
+	}
 
-	keepAlive := time.NewTicker(25 * time.Second)
+	// A reconnecting browser sends the last id it saw; if the history still
+	// holds everything since then, re-render only what changed in the gap.
+	lastID := hub.LastID()
+	if since, ok := realtime.LastEventID(r); ok && hub.Covers(since) {
+		for _, event := range realtime.EventNames(hub.EventsSince(since)) {
+			sendEvent(event)
+		}
+	} else {
+		sendSnapshot(true, true, true)
+	}
+	out.EventID(lastID)
+	out.Retry()
+	out.Flush()
+
+	keepAlive := time.NewTicker(realtime.Heartbeat)
 	defer keepAlive.Stop()
 
 	for {
 		select {
 		case <-r.Context().Done():
 			return
-		case event := <-sub:
-			switch event {
-			case "players":
-				sendSnapshot(false, true, false)
-			case "scores":
-				sendSnapshot(false, false, true)
-			case "round":
-				sendSnapshot(true, false, false)
+		case <-out.Closed():
+			return
+		case event, ok := <-sub:
+			if !ok {
+				return // game deleted
 			}
+			id := hub.LastID()
+			sendEvent(event)
+			out.EventID(id)
+			out.Flush()
 		case <-keepAlive.C:
 			// Comment frame keeps proxies from closing the stream.
-			_, _ = w.Write([]byte(": keepalive\n\n"))
-			flusher.Flush()
+			h.store.TouchPlayer(gameID, playerID)
+			out.KeepAlive()
+			out.Flush()
 		}
 	}
 }
 
 func (h *GameHandler) findPlayerName(r *http.Request, instance *game.Game) (string, bool) {
-	playerID := playerIDFromCookie(r, instance.ID)
+	playerID := PlayerIDFromCookie(r, instance.ID)
 	if playerID == "" {
 		return "", false
 	}
 	return instance.PlayerName(playerID)
 }
 
-func playerIDFromCookie(r *http.Request, gameID string) string {
+// PlayerIDFromCookie returns the player ID the request holds for gameID, or "".
+func PlayerIDFromCookie(r *http.Request, gameID string) string {
 	cookie, err := r.Cookie(playerCookieName(gameID))
 	if err != nil {
 		return ""
@@ -364,7 +968,8 @@ func playerIDFromCookie(r *http.Request, gameID string) string {
 	return cookie.Value
 }
 
-func setPlayerCookie(w http.ResponseWriter, gameID string, playerID string) {
+// SetPlayerCookie seats the client as playerID in gameID.
+func SetPlayerCookie(w http.ResponseWriter, gameID string, playerID string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     playerCookieName(gameID),
 		Value:    playerID,
@@ -395,8 +1000,11 @@ func toScoreEntries(scores []game.ScoreEntry) []viewmodel.ScoreEntry {
 	out := make([]viewmodel.ScoreEntry, 0, len(scores))
 	for _, entry := range scores {
 		out = append(out, viewmodel.ScoreEntry{
+			Handle: entry.Handle,
 			Name:   entry.Name,
 			Points: entry.Points,
+			Delta:  entry.Delta,
+			Color:  entry.Color,
 		})
 	}
 	return out
@@ -411,6 +1019,9 @@ func toPlayerProgress(entries []game.PlayerProgress, excludeName string) []viewm
 		out = append(out, viewmodel.PlayerProgress{
 			Name:    entry.Name,
 			Correct: entry.Correct,
+			Ready:   entry.Ready,
+			Muted:   entry.Muted,
+			Color:   entry.Color,
 		})
 	}
 	return out
@@ -418,6 +1029,12 @@ func toPlayerProgress(entries []game.PlayerProgress, excludeName string) []viewm
 
 func buildRoundFragment(gameID string, snapshot game.Snapshot) viewmodel.RoundFragment {
 	expired := snapshot.Status == game.StatusInProgress && !snapshot.RoundEndedAt.IsZero()
+	// With AllowMultipleWinners a round stays open after the first solve; only
+	// announce the winner (and reveal the word) once it has ended.
+	roundWinner := ""
+	if !snapshot.RoundEndedAt.IsZero() {
+		roundWinner = snapshot.RoundWinner
+	}
 	return viewmodel.RoundFragment{
 		GameID:         gameID,
 		Status:         snapshot.Status,
@@ -428,11 +1045,14 @@ func buildRoundFragment(gameID string, snapshot game.Snapshot) viewmodel.RoundFr
 		Scrambled:      snapshot.RoundData.Scrambled,
 		TargetWord:     snapshot.RoundData.Word,
 		Expired:        expired,
-		RoundWinner:    snapshot.RoundWinner,
+		RoundWinner:    roundWinner,
+		RoundWinners:   snapshot.RoundWinners,
 		RoundEndedMs:   snapshot.RoundEndedAt.UnixMilli(),
 		NextRoundMs:    snapshot.NextRoundAt.UnixMilli(),
-		RoundLocked:    snapshot.RoundWinner != "" || expired,
+		RoundLocked:    roundWinner != "" || expired,
 		RoundKey:       buildRoundKey(snapshot),
+		RevealedWord:   snapshot.RevealedWord,
+		CanStart:       snapshot.HasMinimumPlayers(),
 	}
 }
 
@@ -442,7 +1062,7 @@ func buildRoundKey(snapshot game.Snapshot) string {
 		strconv.Itoa(snapshot.CurrentRound),
 		strconv.FormatInt(snapshot.RoundStarted.UnixMilli(), 10),
 		strconv.FormatInt(snapshot.RoundEndedAt.UnixMilli(), 10),
-		snapshot.RoundWinner,
+		strings.Join(snapshot.RoundWinners, ","),
 	}, "|")
 }
 
@@ -458,7 +1078,8 @@ func correctIndexesForGuess(word string, guess string) []int {
 	}
 	indexes := make([]int, 0, limit)
 	for i := 0; i < limit; i++ {
-		if guessRunes[i] == wordRunes[i] {
+		// Compare per rune so indexes keep pointing at positions in the original word.
+		if unicode.ToLower(guessRunes[i]) == unicode.ToLower(wordRunes[i]) {
 			indexes = append(indexes, i)
 		}
 	}
@@ -475,11 +1096,3 @@ func renderToString(r *http.Request, component templ.Component) string {
 	_ = component.Render(r.Context(), &buf)
 	return buf.String()
 }
-
-func writeSSE(w http.ResponseWriter, event string, data string) {
-	_, _ = w.Write([]byte("event: " + event + "\n"))
-	for _, line := range strings.Split(data, "\n") {
-		_, _ = w.Write([]byte("data: " + line + "\n"))
-	}
-	_, _ = w.Write([]byte("\n"))
-}
@@ -0,0 +1,783 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/internal/game"
+	"dagame/pkg/ratelimit"
+)
+
+func TestCorrectIndexesForGuess_CaseInsensitive(t *testing.T) {
+	for _, tc := range [][2]string{{"TIGER", "tiger"}, {"tiger", "TIGER"}} {
+		got := correctIndexesForGuess(tc[0], tc[1])
+		if len(got) != 5 {
+			t.Fatalf("correctIndexesForGuess(%q, %q) = %v, want all 5 indexes", tc[0], tc[1], got)
+		}
+		for i, idx := range got {
+			if idx != i {
+				t.Errorf("correctIndexesForGuess(%q, %q)[%d] = %d, want %d", tc[0], tc[1], i, idx, i)
+			}
+		}
+	}
+}
+
+func TestCorrectIndexesForGuess_OriginalPositions(t *testing.T) {
+	got := correctIndexesForGuess("Ærlig", "æXlIg")
+	want := []int{0, 2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestStateJSON_HidesWordAndIDs(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	_ = g.Start(time.Now().UTC())
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/state.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control %q, want no-store", got)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, alice.ID) {
+		t.Error("state.json leaks a player ID")
+	}
+	if word := g.CurrentRoundData().Word; strings.Contains(body, `"`+word+`"`) {
+		t.Errorf("state.json leaks the unsolved word %q", word)
+	}
+	var state struct {
+		Status          string `json:"status"`
+		TimeRemainingMs int64  `json:"time_remaining_ms"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Status != game.StatusInProgress || state.TimeRemainingMs <= 0 {
+		t.Errorf("got %+v", state)
+	}
+}
+
+func TestGameHandler_RoundHistory(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	start := time.Now().UTC().Add(-time.Hour)
+	_ = g.Start(start)
+	word := g.CurrentRoundData().Word
+	if ok, _ := g.SubmitGuess(alice.ID, word, start.Add(5*time.Second)); !ok {
+		t.Fatal("SubmitGuess should accept the word")
+	}
+	g.AdvanceIfNeeded(start.Add(10 * time.Minute))
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/history", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	var history []struct {
+		Round        int    `json:"round"`
+		Word         string `json:"word"`
+		Winner       string `json:"winner"`
+		WinnerPoints int    `json:"winner_points"`
+		TimedOut     bool   `json:"timed_out"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("decode %q: %v", rec.Body.String(), err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("got %d rounds, want 1", len(history))
+	}
+	if got := history[0]; got.Round != 1 || got.Word != word || got.Winner != "alice" || got.WinnerPoints != alice.Points || got.TimedOut {
+		t.Errorf("history[0] = %+v, want round 1 %q won by alice for %d pts", got, word, alice.Points)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/missing/history", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown game: status %d, want 404", rec.Code)
+	}
+}
+
+func TestGameHandler_Fragments_ColorPlayerNames(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	for _, path := range []string{"/players", "/scores"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, want 200", path, rec.Code)
+		}
+		if want := `<span style="color:` + alice.Color + `;">alice</span>`; !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s: body missing %s:\n%s", path, want, rec.Body.String())
+		}
+	}
+}
+
+func TestGameHandler_WebSocket_InitialBurst(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	g.AddPlayer("alice")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(500 * time.Millisecond))
+	_, _ = io.WriteString(conn, "GET /game/"+g.ID+"/ws HTTP/1.1\r\nHost: x\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status %d, want 101", resp.StatusCode)
+	}
+	seen := map[string]bool{}
+	for !seen["round"] || !seen["players"] || !seen["scores"] {
+		op, payload, err := readWSFrame(br)
+		if err != nil {
+			t.Fatalf("initial burst incomplete after 500ms (got %v): %v", seen, err)
+		}
+		if op != 0x1 {
+			continue
+		}
+		var msg struct{ Event, Data string }
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Data == "" {
+			t.Errorf("event %q has no HTML", msg.Event)
+		}
+		seen[msg.Event] = true
+	}
+	// Masked close frame from the client ends the stream.
+	_, _ = conn.Write([]byte{0x88, 0x80, 1, 2, 3, 4})
+}
+
+func TestGameHandler_WebSocket_RequiresUpgrade(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/ws", nil))
+	if rec.Code != http.StatusUpgradeRequired {
+		t.Errorf("status %d, want %d", rec.Code, http.StatusUpgradeRequired)
+	}
+}
+
+func TestGameHandler_SubmitGuess_RateLimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int // requests round-robin across this many players
+	}{
+		{"single player", 1},
+		{"two players have separate buckets", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := game.NewStore()
+			g := store.CreateGame(1, time.Minute, "en")
+			t.Cleanup(func() { store.DeleteGame(g.ID) })
+			var ids []string
+			for i := range tt.players {
+				ids = append(ids, g.AddPlayer("p"+strconv.Itoa(i)).ID)
+			}
+
+			r := chi.NewRouter()
+			h := NewGameHandler(store)
+			h.RegisterRoutes(r)
+			guess := func(playerID string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/guess", strings.NewReader("guess=zzz"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Set("Hx-Request", "true")
+				req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: playerID})
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				return rec
+			}
+
+			for i := range 20 * tt.players {
+				rec := guess(ids[i%tt.players])
+				nth := i/tt.players + 1 // this player's nth request
+				if nth <= ratelimit.GuessBurst {
+					if rec.Code == http.StatusTooManyRequests {
+						t.Fatalf("request %d for player %d limited, want allowed", nth, i%tt.players)
+					}
+					continue
+				}
+				if rec.Code != http.StatusTooManyRequests {
+					t.Fatalf("request %d for player %d: status %d, want 429", nth, i%tt.players, rec.Code)
+				}
+				if rec.Header().Get("Retry-After") == "" {
+					t.Fatal("429 without Retry-After")
+				}
+			}
+
+			h.guessLimits.Clear(g.ID)
+			if rec := guess(ids[0]); rec.Code == http.StatusTooManyRequests {
+				t.Error("still limited after clearing the game's limits")
+			}
+		})
+	}
+}
+
+func TestGameHandler_GuessLimits_UnknownPlayersAndDeletedGames(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	_ = g.Start(time.Now().UTC())
+
+	r := chi.NewRouter()
+	h := NewGameHandler(store)
+	h.RegisterRoutes(r)
+	guess := func(playerID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/guess", strings.NewReader("guess=zzz"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Hx-Request", "true")
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := range 50 {
+		if code := guess("forged-" + strconv.Itoa(i)); code != http.StatusSeeOther {
+			t.Fatalf("forged cookie: status %d, want 303", code)
+		}
+	}
+	if n := h.guessLimits.Len(); n != 0 {
+		t.Errorf("%d buckets allocated for unknown players, want 0", n)
+	}
+	if code := guess(alice.ID); code != http.StatusNoContent {
+		t.Fatalf("alice's guess: status %d, want 204", code)
+	}
+	if n := h.guessLimits.Len(); n != 1 {
+		t.Fatalf("%d buckets after alice guessed, want 1", n)
+	}
+	store.DeleteGame(g.ID)
+	if n := h.guessLimits.Len(); n != 0 {
+		t.Errorf("%d buckets left after DeleteGame, want 0", n)
+	}
+}
+
+func TestGameHandler_AwardBonus_ByHandle(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	_ = g.Start(time.Now().UTC())
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	award := func(player string) int {
+		form := url.Values{"player": {player}, "points": {"2"}}
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/bonus", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: owner.ID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := award(bob.ID); code != http.StatusConflict {
+		t.Errorf("award by session ID: status %d, want 409", code)
+	}
+	if code := award(bob.Handle); code != http.StatusSeeOther {
+		t.Fatalf("award by handle: status %d, want 303", code)
+	}
+	if bob.Points != 2 {
+		t.Errorf("bob has %d points, want 2", bob.Points)
+	}
+}
+
+func TestGameHandler_Webhooks(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	post := func(path, contentType, body, asID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: asID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	const form = "application/x-www-form-urlencoded"
+	events := "url=" + url.QueryEscape("https://127.0.0.1/hook") + "&events=game_end"
+
+	if code := post("/webhooks", form, events, bob.ID); code != http.StatusForbidden {
+		t.Errorf("non-owner event webhook: status %d, want 403", code)
+	}
+	if code := post("/webhooks", form, events, owner.ID); code != http.StatusBadRequest {
+		t.Errorf("loopback event webhook: status %d, want 400", code)
+	}
+	if code := post("/webhook", form, events, owner.ID); code != http.StatusBadRequest {
+		t.Errorf("form body on the Discord route: status %d, want 400", code)
+	}
+	if code := post("/webhook", "application/json", `{"url":"https://10.0.0.1/x"}`, owner.ID); code != http.StatusBadRequest {
+		t.Errorf("private Discord webhook: status %d, want 400", code)
+	}
+	if len(g.Webhooks) != 0 || g.WebhookURL != "" {
+		t.Errorf("webhooks registered: %+v %q", g.Webhooks, g.WebhookURL)
+	}
+}
+
+func TestGameHandler_KickPlayer(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	kick := func(asID, handle string) int {
+		req := httptest.NewRequest(http.MethodDelete, "/game/"+g.ID+"/player/"+handle, nil)
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: asID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := kick(bob.ID, owner.Handle); code != http.StatusForbidden {
+		t.Errorf("non-owner kick: status %d, want 403", code)
+	}
+	if code := kick(owner.ID, bob.ID); code != http.StatusConflict {
+		t.Errorf("kick by session ID: status %d, want 409", code)
+	}
+	if code := kick(owner.ID, bob.Handle); code != http.StatusNoContent {
+		t.Fatalf("owner kick: status %d, want 204", code)
+	}
+	if code := kick(owner.ID, bob.Handle); code != http.StatusConflict {
+		t.Errorf("repeat kick: status %d, want 409", code)
+	}
+	if _, ok := g.PlayerName(bob.ID); ok {
+		t.Error("bob still in the game after kick")
+	}
+}
+
+func TestGameHandler_MutePlayer(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	_ = g.Start(time.Now().UTC())
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	post := func(path, asID string, form url.Values) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: asID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post("/mute", bob.ID, url.Values{"player": {owner.Handle}}); code != http.StatusForbidden {
+		t.Errorf("non-owner mute: status %d, want 403", code)
+	}
+	if code := post("/mute", owner.ID, url.Values{"player": {bob.ID}}); code != http.StatusConflict {
+		t.Errorf("mute by session ID: status %d, want 409", code)
+	}
+	if code := post("/mute", owner.ID, url.Values{"player": {bob.Handle}}); code != http.StatusSeeOther {
+		t.Fatalf("owner mute: status %d, want 303", code)
+	}
+	if !g.IsMuted(bob.ID) {
+		t.Fatal("bob not muted")
+	}
+	word := g.CurrentRoundData().Word
+	if code := post("/guess", bob.ID, url.Values{"guess": {word}}); code != http.StatusForbidden {
+		t.Errorf("muted guess: status %d, want 403", code)
+	}
+	if code := post("/unmute", owner.ID, url.Values{"player": {bob.Handle}}); code != http.StatusSeeOther {
+		t.Errorf("owner unmute: status %d, want 303", code)
+	}
+	if g.IsMuted(bob.ID) {
+		t.Error("bob still muted after unmute")
+	}
+}
+
+func TestGameHandler_ReadyUp(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	ready := func(asID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/ready", nil)
+		if asID != "" {
+			req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: asID})
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := ready(""); code != http.StatusForbidden {
+		t.Errorf("ready without a player: status %d, want 403", code)
+	}
+	if code := ready(alice.ID); code != http.StatusNoContent {
+		t.Errorf("ready(alice): status %d, want 204", code)
+	}
+	if code := ready(bob.ID); code != http.StatusNoContent {
+		t.Errorf("ready(bob): status %d, want 204", code)
+	}
+	if snap := g.Snapshot(time.Now().UTC()); snap.Status != game.StatusInProgress {
+		t.Errorf("Status %q after everyone readied, want %q", snap.Status, game.StatusInProgress)
+	}
+	if code := ready(alice.ID); code != http.StatusConflict {
+		t.Errorf("ready after start: status %d, want 409", code)
+	}
+}
+
+func TestGameHandler_TouchesPlayerOnRequest(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	alice := g.AddPlayer("alice")
+	stale := time.Now().UTC().Add(-time.Hour)
+	alice.LastSeenAt = stale
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/scores", nil)
+	req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: alice.ID})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !alice.LastSeenAt.After(stale) {
+		t.Errorf("LastSeenAt = %v, want updated by the request", alice.LastSeenAt)
+	}
+	if pruned := g.PruneInactivePlayers(game.InactivityThreshold, time.Now().UTC()); len(pruned) != 0 {
+		t.Errorf("pruned %q right after a request", pruned)
+	}
+}
+
+func TestGameHandler_TransferOwnership(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	transfer := func(asID, newOwner string) int {
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/transfer", strings.NewReader("newOwner="+newOwner))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: asID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	roundAs := func(playerID string) string {
+		req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/round", nil)
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	if code := transfer(bob.ID, bob.Handle); code != http.StatusForbidden {
+		t.Errorf("non-owner transfer: status %d, want 403", code)
+	}
+	if code := transfer(owner.ID, "missing"); code != http.StatusConflict {
+		t.Errorf("transfer to unknown player: status %d, want 409", code)
+	}
+	if strings.Contains(roundAs(bob.ID), "Start game") {
+		t.Error("bob sees the Start button before owning the game")
+	}
+	if code := transfer(owner.ID, bob.ID); code != http.StatusConflict {
+		t.Errorf("transfer by session ID: status %d, want 409", code)
+	}
+	if code := transfer(owner.ID, bob.Handle); code != http.StatusSeeOther {
+		t.Fatalf("owner transfer: status %d, want 303", code)
+	}
+	if !g.IsOwner(bob.ID) {
+		t.Fatal("bob does not own the game after the transfer")
+	}
+	if !strings.Contains(roundAs(bob.ID), "Start game") {
+		t.Error("new owner does not see the Start button")
+	}
+	if strings.Contains(roundAs(owner.ID), "Start game") {
+		t.Error("old owner still sees the Start button")
+	}
+}
+
+func TestGameHandler_ScoresFragment_OwnerFormsUseHandles(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	owner := g.AddPlayer("alice")
+	twin := g.AddPlayer("alice") // same username as the owner
+	_ = g.Start(time.Now().UTC())
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/scores", nil)
+	req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: owner.ID})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	body := rec.Body.String()
+
+	for _, id := range []string{owner.ID, twin.ID} {
+		if strings.Contains(body, id) {
+			t.Errorf("scores fragment leaks session ID %q", id)
+		}
+	}
+	if got := strings.Count(body, `value="`+twin.Handle+`"`); got != 2 {
+		t.Errorf("twin's handle appears in %d options, want bonus and new-owner lists", got)
+	}
+	if got := strings.Count(body, `value="`+owner.Handle+`"`); got != 1 {
+		t.Errorf("owner's handle appears in %d options, want only the bonus list", got)
+	}
+}
+
+func TestGameHandler_JoinGame_Password(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	if err := g.SetPassword("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	join := func(password string) *httptest.ResponseRecorder {
+		form := "username=alice&password=" + password
+		req := httptest.NewRequest(http.MethodPost, "/game/"+g.ID+"/join", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, wrong := range []string{"", "hunter", "hunter22"} {
+		rec := join(wrong)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("password %q: status %d, want 403", wrong, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "Wrong password") {
+			t.Errorf("password %q: body %q lacks the error fragment", wrong, rec.Body.String())
+		}
+	}
+	if n := g.PlayerCount(); n != 0 {
+		t.Fatalf("PlayerCount = %d after rejected joins, want 0", n)
+	}
+	if rec := join("hunter2"); rec.Code != http.StatusSeeOther {
+		t.Fatalf("correct password: status %d, want 303", rec.Code)
+	}
+	if n := g.PlayerCount(); n != 1 {
+		t.Errorf("PlayerCount = %d, want 1", n)
+	}
+	snap := g.Snapshot(time.Now())
+	if !snap.IsProtected {
+		t.Error("Snapshot.IsProtected = false for a password game")
+	}
+	if b, _ := json.Marshal(snap); strings.Contains(string(b), "hunter2") {
+		t.Error("snapshot JSON leaks the password")
+	}
+}
+
+func TestGameHandler_GameStats(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	g.AddPlayer("alice")
+	g.AddPlayer("bob")
+	g.AddSpectator("carol")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	stats := func() gameStatsJSON {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/stats", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status %d, want 200", rec.Code)
+		}
+		var got gameStatsJSON
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if got, want := stats(), (gameStatsJSON{Players: 2, Connections: 0, Status: game.StatusLobby}); got != want {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+	ch := store.Broadcaster(g.ID).Subscribe()
+	if got := stats().Connections; got != 1 {
+		t.Errorf("connections after subscribe = %d, want 1", got)
+	}
+	store.Broadcaster(g.ID).Unsubscribe(ch)
+	if got := stats().Connections; got != 0 {
+		t.Errorf("connections after unsubscribe = %d, want 0", got)
+	}
+	if n := store.ActiveConnectionCount("missing"); n != 0 {
+		t.Errorf("ActiveConnectionCount(missing) = %d, want 0", n)
+	}
+}
+
+// readWSFrame reads one unmasked server frame.
+func readWSFrame(br *bufio.Reader) (op byte, payload []byte, err error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(br, head); err != nil {
+		return 0, nil, err
+	}
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(br, ext); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(br, ext); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext)
+	}
+	payload = make([]byte, n)
+	_, err = io.ReadFull(br, payload)
+	return head[0] & 0x0F, payload, err
+}
+
+func TestGameHandler_Stream_ReplaysSinceLastEventID(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	g.AddPlayer("alice")
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // the handler writes its initial burst, then returns
+	open := func(lastEventID string) string {
+		req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/stream", nil).WithContext(ctx)
+		req.Header.Set("Accept", "text/event-stream")
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	store.Publish(g.ID, "round")
+	seen := store.Broadcaster(g.ID).LastID()
+	store.Publish(g.ID, "scores") // missed while disconnected
+
+	body := open(strconv.FormatInt(seen, 10))
+	if !strings.Contains(body, "event: scores\n") {
+		t.Errorf("replay missing scores event:\n%s", body)
+	}
+	if strings.Contains(body, "event: round\n") || strings.Contains(body, "event: players\n") {
+		t.Errorf("replay resent events from before Last-Event-ID:\n%s", body)
+	}
+	if want := "id: " + strconv.FormatInt(seen+1, 10) + "\n"; !strings.Contains(body, want) {
+		t.Errorf("stream missing %q:\n%s", want, body)
+	}
+
+	// An id the history no longer covers falls back to the full burst.
+	body = open("999")
+	for _, event := range []string{"round", "players", "scores"} {
+		if !strings.Contains(body, "event: "+event+"\n") {
+			t.Errorf("full burst missing %s event", event)
+		}
+	}
+}
+
+func TestGameHandler_RoundFragment_MultipleWinnersHidesWord(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	t.Cleanup(func() { store.DeleteGame(g.ID) })
+	g.AllowMultipleWinners = true
+	alice := g.AddPlayer("alice")
+	bob := g.AddPlayer("bob")
+	now := time.Now().UTC()
+	_ = g.Start(now)
+	if ok, _ := g.SubmitGuess(alice.ID, g.CurrentRoundData().Word, now); !ok {
+		t.Fatal("alice's correct guess was rejected")
+	}
+
+	r := chi.NewRouter()
+	NewGameHandler(store).RegisterRoutes(r)
+	fragment := func(playerID string) string {
+		req := httptest.NewRequest(http.MethodGet, "/game/"+g.ID+"/round", nil)
+		req.AddCookie(&http.Cookie{Name: playerCookieName(g.ID), Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	if body := fragment(bob.ID); strings.Contains(body, "is-solved") || !strings.Contains(body, "data-guess-form") {
+		t.Errorf("bob should still be guessing without seeing the word:\n%s", body)
+	}
+	if body := fragment(alice.ID); !strings.Contains(body, "is-solved") || strings.Contains(body, "data-guess-form") {
+		t.Errorf("alice should see the solved word and no guess form:\n%s", body)
+	}
+}
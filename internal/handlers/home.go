@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +32,7 @@ func (h *HomeHandler) RegisterRoutes(r chi.Router) {
 var langLabels = map[string]string{
 	"en": "English",
 	"no": "Norwegian",
+	"fr": "French",
 }
 
 func (h *HomeHandler) home(w http.ResponseWriter, r *http.Request) {
@@ -70,10 +72,37 @@ func (h *HomeHandler) createGame(w http.ResponseWriter, r *http.Request) {
 		durationSec = 300
 	}
 
-	gameInstance := h.store.CreateGame(rounds, time.Duration(durationSec)*time.Second, lang)
+	category := strings.TrimSpace(r.FormValue("category"))
+	password := r.FormValue("password")
+	if len(password) > game.MaxPasswordLength {
+		http.Error(w, "password is too long", http.StatusBadRequest)
+		return
+	}
+
+	gameInstance := h.store.CreateGameWithCategory(rounds, time.Duration(durationSec)*time.Second, lang, category)
+	gameInstance.TimeBonusMultiplier = parseTimeBonus(r.FormValue("time_bonus_multiplier"))
+	gameInstance.SetFuzzyTolerance(parseInt(r.FormValue("fuzzy"), 0))
+	gameInstance.AllowMultipleWinners = r.FormValue("multiple_winners") == "on"
+	if err := gameInstance.SetPassword(password); err != nil {
+		h.store.DeleteGame(gameInstance.ID)
+		http.Error(w, "could not set password", http.StatusInternalServerError)
+		return
+	}
 	http.Redirect(w, r, "/game/"+gameInstance.ID, http.StatusSeeOther)
 }
 
+// timeBonusChoices are the multipliers offered on the create form.
+var timeBonusChoices = []float64{1.0, 1.5, 2.0, 3.0}
+
+// parseTimeBonus returns the chosen multiplier, or 1.0 for anything not offered.
+func parseTimeBonus(value string) float64 {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || !slices.Contains(timeBonusChoices, parsed) {
+		return 1.0
+	}
+	return parsed
+}
+
 func parseInt(value string, fallback int) int {
 	if value == "" {
 		return fallback
@@ -0,0 +1,33 @@
+// Package logger builds the servers' slog logger: JSON lines when LOG_FORMAT
+// is "json", otherwise key=value text, both on stderr.
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	once    sync.Once
+	current *slog.Logger
+)
+
+// Logger returns the process logger, configured from LOG_FORMAT on first use.
+func Logger() *slog.Logger {
+	once.Do(func() {
+		current = New(os.Stderr, os.Getenv("LOG_FORMAT"))
+	})
+	return current
+}
+
+// New returns a logger writing to w, as JSON when format is "json" and as
+// text otherwise.
+func New(w io.Writer, format string) *slog.Logger {
+	if strings.EqualFold(strings.TrimSpace(format), "json") {
+		return slog.New(slog.NewJSONHandler(w, nil))
+	}
+	return slog.New(slog.NewTextHandler(w, nil))
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, "json")
+	log.Info("game created", slog.String("gameID", "abc"), slog.Int("rounds", 3))
+	log.Error("save game", slog.String("gameID", "abc"), slog.Any("err", "disk full"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", lines[0], err)
+	}
+	if entry["msg"] != "game created" || entry["gameID"] != "abc" || entry["rounds"] != float64(3) {
+		t.Errorf("entry = %v", entry)
+	}
+	if !json.Valid([]byte(lines[1])) {
+		t.Errorf("line %q is not JSON", lines[1])
+	}
+}
+
+func TestNew_TextByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, "").Info("listening", slog.String("addr", ":8080"))
+	if line := buf.String(); json.Valid([]byte(line)) || !strings.Contains(line, "addr=:8080") {
+		t.Errorf("text log = %q", line)
+	}
+}
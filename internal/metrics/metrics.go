@@ -0,0 +1,107 @@
+// Package metrics counts game server activity for the /metrics endpoint,
+// in the Prometheus text format, and for expvar.
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Gauges are point-in-time values read from the store on every scrape, so
+// they cannot drift from the real state.
+type Gauges struct {
+	ActiveGames int `json:"active_games"`
+	Subscribers int `json:"sse_subscribers"`
+	RoundLoops  int `json:"round_loops"`
+}
+
+// Snapshot is every metric at one moment.
+type Snapshot struct {
+	GamesCreated int64 `json:"games_created_total"`
+	Guesses      int64 `json:"guesses_total"`
+	Gauges
+}
+
+// Collector holds one server's metrics. A nil *Collector is valid and
+// ignores every call, so stores can use it unconditionally.
+type Collector struct {
+	namespace    string
+	gamesCreated expvar.Int
+	guesses      expvar.Int
+
+	mu     sync.Mutex
+	gauges func() Gauges
+}
+
+// New returns a Collector whose metric names start with namespace, e.g.
+// "unscrambler_games_created_total".
+func New(namespace string) *Collector {
+	return &Collector{namespace: namespace}
+}
+
+// GameCreated counts a new game.
+func (c *Collector) GameCreated() {
+	if c != nil {
+		c.gamesCreated.Add(1)
+	}
+}
+
+// GuessSubmitted counts a guess, right or wrong.
+func (c *Collector) GuessSubmitted() {
+	if c != nil {
+		c.guesses.Add(1)
+	}
+}
+
+// SetGaugeSource sets the function read for gauge values on every scrape.
+func (c *Collector) SetGaugeSource(fn func() Gauges) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gauges = fn
+}
+
+// Snapshot returns the current values.
+func (c *Collector) Snapshot() Snapshot {
+	if c == nil {
+		return Snapshot{}
+	}
+	c.mu.Lock()
+	fn := c.gauges
+	c.mu.Unlock()
+	snap := Snapshot{GamesCreated: c.gamesCreated.Value(), Guesses: c.guesses.Value()}
+	if fn != nil {
+		snap.Gauges = fn()
+	}
+	return snap
+}
+
+// String implements expvar.Var, so the collector can be passed to expvar.Publish.
+func (c *Collector) String() string {
+	b, _ := json.Marshal(c.Snapshot())
+	return string(b)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	snap := c.Snapshot()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, m := range []struct {
+		name, kind, help string
+		value            int64
+	}{
+		{"games_active", "gauge", "Games currently held in memory.", int64(snap.ActiveGames)},
+		{"games_created_total", "counter", "Games created since the server started.", snap.GamesCreated},
+		{"sse_subscribers", "gauge", "Open event streams across all games.", int64(snap.Subscribers)},
+		{"round_loops", "gauge", "Running round-timer goroutines.", int64(snap.RoundLoops)},
+		{"guesses_total", "counter", "Guesses submitted since the server started.", snap.Guesses},
+	} {
+		name := c.namespace + "_" + m.name
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", name, m.help, name, m.kind, name, m.value)
+	}
+}
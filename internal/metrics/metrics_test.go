@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCollector_NilIsNoOp(t *testing.T) {
+	var c *Collector
+	c.GameCreated()
+	c.GuessSubmitted()
+	c.SetGaugeSource(func() Gauges { return Gauges{} })
+	if got := c.Snapshot(); got != (Snapshot{}) {
+		t.Errorf("nil Snapshot = %+v, want zero", got)
+	}
+}
+
+func TestCollector_ServeHTTP(t *testing.T) {
+	c := New("test")
+	c.GameCreated()
+	c.GameCreated()
+	c.GuessSubmitted()
+	c.SetGaugeSource(func() Gauges { return Gauges{ActiveGames: 2, Subscribers: 3, RoundLoops: 1} })
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE test_games_created_total counter\ntest_games_created_total 2\n",
+		"# TYPE test_games_active gauge\ntest_games_active 2\n",
+		"test_sse_subscribers 3\n",
+		"test_round_loops 1\n",
+		"test_guesses_total 1\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q:\n%s", want, body)
+		}
+	}
+	if got := c.String(); !strings.Contains(got, `"games_created_total":2`) {
+		t.Errorf("expvar String() = %s", got)
+	}
+}
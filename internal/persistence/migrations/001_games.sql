@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS games (
+	id         TEXT PRIMARY KEY,
+	state      TEXT NOT NULL, -- game.Game as JSON
+	updated_at TIMESTAMP NOT NULL
+);
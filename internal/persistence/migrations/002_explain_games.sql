@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS explain_games (
+	id         TEXT PRIMARY KEY,
+	state      TEXT NOT NULL, -- explain.Game as JSON
+	updated_at TIMESTAMP NOT NULL
+);
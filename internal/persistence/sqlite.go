@@ -0,0 +1,187 @@
+// Package persistence stores games in SQLite so they survive a server restart.
+// It links the cgo driver github.com/mattn/go-sqlite3.
+package persistence
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // registers DriverName
+
+	"dagame/internal/explain"
+	"dagame/internal/game"
+)
+
+// DriverName is the database/sql driver Open uses.
+const DriverName = "sqlite3"
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// GameRepository persists unscrambler games.
+type GameRepository = game.Repository
+
+// ExplainRepository persists explain games.
+type ExplainRepository = explain.Repository
+
+// SQLiteStore keeps each game as a JSON document in its own row. It
+// implements GameRepository; Explain returns the ExplainRepository backed by
+// the same database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var _ GameRepository = (*SQLiteStore)(nil)
+
+// Open opens the SQLite database at dsn (a file path, or ":memory:") and runs
+// any pending migrations.
+func Open(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time, and each ":memory:" connection
+	// would otherwise get its own empty database.
+	db.SetMaxOpenConns(1)
+	s, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New wraps an open database and runs any pending migrations.
+func New(db *sql.DB) (*SQLiteStore, error) {
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("persistence: migrate: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Save writes g, replacing any earlier copy.
+func (s *SQLiteStore) Save(g *game.Game) error {
+	state, err := g.EncodeState()
+	if err != nil {
+		return err
+	}
+	return saveState(s.db, "games", g.ID, state)
+}
+
+// Load reads the game with id, or returns game.ErrGameNotFound.
+func (s *SQLiteStore) Load(id string) (*game.Game, error) {
+	state, err := loadState(s.db, "games", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, game.ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return game.DecodeState(state)
+}
+
+// Delete removes the game with id. Deleting an unknown ID is not an error.
+func (s *SQLiteStore) Delete(id string) error {
+	_, err := s.db.Exec(`DELETE FROM games WHERE id = ?`, id)
+	return err
+}
+
+// Explain returns the repository for explain games.
+func (s *SQLiteStore) Explain() ExplainRepository {
+	return explainGames{db: s.db}
+}
+
+type explainGames struct {
+	db *sql.DB
+}
+
+func (e explainGames) Save(g *explain.Game) error {
+	state, err := g.EncodeState()
+	if err != nil {
+		return err
+	}
+	return saveState(e.db, "explain_games", g.ID, state)
+}
+
+func (e explainGames) Load(id string) (*explain.Game, error) {
+	state, err := loadState(e.db, "explain_games", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, explain.ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return explain.DecodeState(state)
+}
+
+// saveState upserts one game row. table is one of the constant names above,
+// never user input.
+func saveState(db *sql.DB, table, id string, state []byte) error {
+	_, err := db.Exec(`INSERT INTO `+table+` (id, state, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET state = excluded.state, updated_at = excluded.updated_at`,
+		id, string(state), time.Now().UTC())
+	return err
+}
+
+func loadState(db *sql.DB, table, id string) ([]byte, error) {
+	var state string
+	if err := db.QueryRow(`SELECT state FROM `+table+` WHERE id = ?`, id).Scan(&state); err != nil {
+		return nil, err
+	}
+	return []byte(state), nil
+}
+
+// migrate applies the embedded migrations in file-name order, recording each
+// in schema_migrations so it runs once.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return err
+	}
+	names, err := fs.Glob(migrationsFS, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var applied int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, name).Scan(&applied); err != nil {
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+		body, err := fs.ReadFile(migrationsFS, name)
+		if err != nil {
+			return err
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(string(body)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, name, time.Now().UTC()); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
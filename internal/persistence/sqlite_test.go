@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"dagame/internal/explain"
+	"dagame/internal/game"
+)
+
+// openTestStore opens an in-memory database.
+func openTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	s, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestOpen_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	g := game.NewGame(1, time.Minute, "en")
+	if err := s.Save(g); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	s.Close()
+
+	// Reopening runs the migrations again and finds the saved game.
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if _, err := s.Load(g.ID); err != nil {
+		t.Errorf("Load after reopen: %v", err)
+	}
+}
+
+func TestSQLiteStore_SaveLoad(t *testing.T) {
+	s := openTestStore(t)
+	g := game.NewGame(2, time.Minute, "en")
+	g.AddPlayer("alice")
+	if err := s.Save(g); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	g.AddPlayer("bob")
+	if err := s.Save(g); err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+	got, err := s.Load(g.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.PlayerCount() != 2 {
+		t.Errorf("loaded PlayerCount = %d, want 2 from the latest save", got.PlayerCount())
+	}
+	if err := s.Delete(g.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Load(g.ID); !errors.Is(err, game.ErrGameNotFound) {
+		t.Errorf("Load after Delete = %v, want ErrGameNotFound", err)
+	}
+}
+
+func TestSQLiteStore_Explain(t *testing.T) {
+	s := openTestStore(t)
+	repo := s.Explain()
+	g := explain.NewGame(1, time.Minute, "en", explain.DefaultEmojisPerRound)
+	g.AddPlayer("alice")
+	if err := repo.Save(g); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := repo.Load(g.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.PlayerCount() != 1 {
+		t.Errorf("loaded PlayerCount = %d, want 1", got.PlayerCount())
+	}
+	// The two games use separate tables.
+	if _, err := s.Load(g.ID); !errors.Is(err, game.ErrGameNotFound) {
+		t.Errorf("unscrambler Load of an explain ID = %v, want ErrGameNotFound", err)
+	}
+	if _, err := repo.Load("missing"); !errors.Is(err, explain.ErrGameNotFound) {
+		t.Errorf("Load(missing) = %v, want ErrGameNotFound", err)
+	}
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	s := openTestStore(t)
+	if err := migrate(s.db); err != nil {
+		t.Fatalf("second migrate: %v", err)
+	}
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("schema_migrations has %d rows, want 2", n)
+	}
+}
@@ -0,0 +1,51 @@
+// Package server runs an HTTP server next to a game store and shuts both
+// down together.
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Store is the part of a game store Run needs at shutdown.
+type Store interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Run serves srv on ln until ctx ends, then shuts it down, giving requests up
+// to timeout to finish. http.Server.Shutdown waits for open streams but never
+// interrupts them, so a RegisterOnShutdown hook drains and closes store as
+// soon as shutdown starts; closing the rooms ends the streams. Run returns
+// once the server and the store are both done.
+func Run(ctx context.Context, srv *http.Server, ln net.Listener, store Store, timeout time.Duration) error {
+	storeClosed := make(chan struct{})
+	srv.RegisterOnShutdown(func() {
+		defer close(storeClosed)
+		drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := store.Shutdown(drainCtx); err != nil {
+			slog.Error("shut down store", slog.Any("err", err))
+		}
+	})
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ln) }()
+	select {
+	case err := <-served:
+		return err // Serve failed before shutdown started
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	<-storeClosed
+	if serveErr := <-served; !errors.Is(serveErr, http.ErrServerClosed) {
+		err = errors.Join(err, serveErr)
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/internal/game"
+	"dagame/internal/handlers"
+)
+
+func TestRun_ShutdownEndsOpenStreams(t *testing.T) {
+	store := game.NewStore()
+	g := store.CreateGame(1, time.Minute, "en")
+	r := chi.NewRouter()
+	handlers.NewGameHandler(store).RegisterRoutes(r)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const timeout = 5 * time.Second
+	done := make(chan error, 1)
+	go func() { done <- Run(ctx, &http.Server{Handler: r}, ln, store, timeout) }()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+ln.Addr().String()+"/game/"+g.ID+"/stream", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body := bufio.NewReader(resp.Body)
+	if line, err := body.ReadString('\n'); err != nil || !strings.HasPrefix(line, "event: ") {
+		t.Fatalf("first stream line %q, %v", line, err)
+	}
+
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(timeout):
+		t.Fatal("Run did not return; the open stream held up shutdown")
+	}
+	if elapsed := time.Since(start); elapsed > timeout/2 {
+		t.Errorf("shutdown took %s with an open stream", elapsed)
+	}
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		t.Errorf("stream did not end cleanly: %v", err)
+	}
+	if stats := store.Stats(); stats.TotalGames != 0 {
+		t.Errorf("TotalGames = %d after shutdown, want 0", stats.TotalGames)
+	}
+}
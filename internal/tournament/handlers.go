@@ -0,0 +1,152 @@
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/internal/game"
+	"dagame/internal/handlers"
+	"dagame/pkg/ratelimit"
+)
+
+// Tournament creation is limited server-wide, since one request opens up to
+// MaxOpeningGames games.
+const (
+	createsPerMinute = 10
+	createBurst      = 5
+)
+
+// TournamentHandler serves tournament creation and bracket state.
+type TournamentHandler struct {
+	store       *TournamentStore
+	createLimit *ratelimit.Bucket
+}
+
+// NewTournamentHandler builds the handler for tournament routes.
+func NewTournamentHandler(store *TournamentStore) *TournamentHandler {
+	return &TournamentHandler{
+		store:       store,
+		createLimit: ratelimit.NewBucket(createsPerMinute/60.0, createBurst),
+	}
+}
+
+// RegisterRoutes wires the tournament endpoints.
+func (h *TournamentHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/tournaments", h.create)
+	r.Get("/tournaments/{id}", h.show)
+	r.Get("/tournaments/{id}/bracket", h.bracket)
+	r.Post("/tournaments/{id}/advance", h.advance)
+}
+
+type gameLinkJSON struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+}
+
+type tournamentJSON struct {
+	ID       string         `json:"id"`
+	Games    []gameLinkJSON `json:"games"`
+	Winners  []string       `json:"winners"`
+	Champion string         `json:"champion"`
+}
+
+// create accepts form fields games (2, 4 or 8), rounds, duration and lang,
+// matching the single-game form, and redirects to the new tournament.
+func (h *TournamentHandler) create(w http.ResponseWriter, r *http.Request) {
+	if ok, wait := h.createLimit.Allow(time.Now()); !ok {
+		ratelimit.TooManyRequests(w, wait, "too many tournaments created; try again shortly")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	rounds := min(max(formInt(r, "rounds", 3), 1), 10)
+	durationSec := min(max(formInt(r, "duration", 60), 10), 300)
+	lang := strings.TrimSpace(r.FormValue("lang"))
+	if lang == "" {
+		lang = "en"
+	}
+	t, err := h.store.Create(formInt(r, "games", 4), rounds, time.Duration(durationSec)*time.Second, lang)
+	if errors.Is(err, ErrTooManyTournaments) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/tournaments/"+t.ID, http.StatusSeeOther)
+}
+
+// advance moves a winner of the game named by the "game" form field into
+// their seat in the next bracket round and redirects them there.
+func (h *TournamentHandler) advance(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.store.Get(chi.URLParam(r, "id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	from := r.FormValue("game")
+	nextGameID, nextPlayerID, ok := t.Seat(from, handlers.PlayerIDFromCookie(r, from))
+	if !ok {
+		http.Error(w, "no seat in the next round", http.StatusNotFound)
+		return
+	}
+	handlers.SetPlayerCookie(w, nextGameID, nextPlayerID)
+	http.Redirect(w, r, "/game/"+nextGameID, http.StatusSeeOther)
+}
+
+func (h *TournamentHandler) show(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.store.Get(chi.URLParam(r, "id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	now := time.Now().UTC()
+	t.mu.Lock()
+	out := tournamentJSON{
+		ID:       t.ID,
+		Games:    make([]gameLinkJSON, 0, len(t.Games)),
+		Winners:  append([]string{}, t.Winners...),
+		Champion: t.Champion,
+	}
+	games := append([]*game.Game{}, t.Games...)
+	t.mu.Unlock()
+	for _, g := range games {
+		out.Games = append(out.Games, gameLinkJSON{ID: g.ID, URL: "/game/" + g.ID, Status: g.Snapshot(now).Status})
+	}
+	writeJSON(w, out)
+}
+
+func (h *TournamentHandler) bracket(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.store.Get(chi.URLParam(r, "id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	t.mu.Lock()
+	bracket := append([]BracketRound{}, t.Bracket...)
+	t.mu.Unlock()
+	writeJSON(w, map[string]any{"rounds": bracket})
+}
+
+func formInt(r *http.Request, key string, fallback int) int {
+	v, err := strconv.Atoi(r.FormValue(key))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,249 @@
+// Package tournament groups unscrambler games into a knockout bracket.
+package tournament
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"dagame/internal/game"
+)
+
+// MaxOpeningGames caps the first bracket round.
+const MaxOpeningGames = 8
+
+// MaxTournaments caps how many tournaments a store holds at once, since each
+// one creates up to MaxOpeningGames games.
+const MaxTournaments = 50
+
+// ErrTooManyTournaments is returned by Create when the store is full.
+var ErrTooManyTournaments = errors.New("too many tournaments in progress")
+
+// BracketRound is one stage of the bracket. Games are paired in order
+// (0 vs 1, 2 vs 3, ...); once both games of a pair finish, each winner is
+// promoted into a shared game in the next round. A round with a single game
+// is the final.
+type BracketRound struct {
+	GameIDs []string `json:"gameIds"`
+}
+
+// Tournament is a knockout bracket of games sharing one configuration.
+type Tournament struct {
+	mu       sync.Mutex
+	ID       string
+	Games    []*game.Game // every game in the bracket, in creation order
+	Bracket  []BracketRound
+	Winners  []string // player names promoted out of a round, in promotion order
+	Champion string   // winner of the final; empty until it finishes
+
+	rounds    int
+	duration  time.Duration
+	lang      string
+	createdAt time.Time
+	promoted  map[int][]entrant   // index of the first game of a pair in the latest bracket round -> its winners
+	seats     map[seatKey]seatKey // a winner's player in one game -> their seat in the next
+}
+
+// entrant is a player who won a game and waits for the next bracket round.
+type entrant struct {
+	gameID   string
+	playerID string
+	name     string
+}
+
+// seatKey identifies a player within a game.
+type seatKey struct {
+	gameID   string
+	playerID string
+}
+
+// TournamentStore creates tournaments whose games live in a game.Store, so
+// they are played through the regular game pages.
+type TournamentStore struct {
+	games *game.Store
+
+	mu          sync.Mutex
+	tournaments map[string]*Tournament
+}
+
+// NewTournamentStore wraps games.
+func NewTournamentStore(games *game.Store) *TournamentStore {
+	return &TournamentStore{games: games, tournaments: make(map[string]*Tournament)}
+}
+
+// Create opens a tournament with openingGames first-round games, which must
+// be a power of two between 2 and MaxOpeningGames.
+func (s *TournamentStore) Create(openingGames, rounds int, duration time.Duration, lang string) (*Tournament, error) {
+	if openingGames < 2 || openingGames > MaxOpeningGames || openingGames&(openingGames-1) != 0 {
+		return nil, errors.New("opening games must be 2, 4 or 8")
+	}
+	t := &Tournament{
+		ID:        newID(),
+		rounds:    rounds,
+		duration:  duration,
+		lang:      lang,
+		createdAt: time.Now().UTC(),
+		promoted:  make(map[int][]entrant),
+		seats:     make(map[seatKey]seatKey),
+	}
+
+	// Reserve the slot before creating games so a full store creates none.
+	// t.mu is held until the first round exists, so Get never sees it empty.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s.mu.Lock()
+	if len(s.tournaments) >= MaxTournaments {
+		s.mu.Unlock()
+		return nil, ErrTooManyTournaments
+	}
+	s.tournaments[t.ID] = t
+	s.mu.Unlock()
+
+	first := BracketRound{}
+	for i := 0; i < openingGames; i++ {
+		first.GameIDs = append(first.GameIDs, t.addGameLocked(s.games).ID)
+	}
+	t.Bracket = append(t.Bracket, first)
+	return t, nil
+}
+
+// cleanupInterval is the longest StartCleanupLoop waits between sweeps.
+const cleanupInterval = 10 * time.Minute
+
+// StartCleanupLoop removes tournaments older than maxAge in the background
+// until ctx is done. Their games expire through the game store's own loop.
+func (s *TournamentStore) StartCleanupLoop(ctx context.Context, maxAge time.Duration) {
+	interval := min(maxAge, cleanupInterval)
+	if interval <= 0 {
+		interval = cleanupInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.deleteExpired(maxAge, time.Now().UTC())
+			}
+		}
+	}()
+}
+
+// deleteExpired forgets every tournament created more than maxAge before now.
+func (s *TournamentStore) deleteExpired(maxAge time.Duration, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.tournaments {
+		if now.Sub(t.createdAt) > maxAge {
+			delete(s.tournaments, id)
+		}
+	}
+}
+
+// Get returns a tournament after promoting any newly finished pairs.
+func (s *TournamentStore) Get(id string) (*Tournament, bool) {
+	s.mu.Lock()
+	t, ok := s.tournaments[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	t.Refresh(s.games, time.Now().UTC())
+	return t, true
+}
+
+// Seat reports where the winner playing as playerID in gameID continues: the
+// next round's game and their player ID in it.
+func (t *Tournament) Seat(gameID, playerID string) (nextGameID, nextPlayerID string, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	next, ok := t.seats[seatKey{gameID: gameID, playerID: playerID}]
+	return next.gameID, next.playerID, ok
+}
+
+// Refresh promotes the winners of every pair in the latest bracket round whose
+// games have both finished. Once all pairs are done it opens the next round,
+// seating each pair's winners together in one of its games.
+func (t *Tournament) Refresh(games *game.Store, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Champion != "" {
+		return
+	}
+	latest := t.Bracket[len(t.Bracket)-1]
+	if len(latest.GameIDs) == 1 {
+		if winner, done := gameWinner(games, latest.GameIDs[0], now); done {
+			t.Champion = winner.name
+		}
+		return
+	}
+	for i := 0; i+1 < len(latest.GameIDs); i += 2 {
+		if _, ok := t.promoted[i]; ok {
+			continue
+		}
+		a, doneA := gameWinner(games, latest.GameIDs[i], now)
+		b, doneB := gameWinner(games, latest.GameIDs[i+1], now)
+		if !doneA || !doneB {
+			continue
+		}
+		var winners []entrant
+		for _, e := range []entrant{a, b} {
+			if e.name != "" {
+				t.Winners = append(t.Winners, e.name)
+				winners = append(winners, e)
+			}
+		}
+		t.promoted[i] = winners
+	}
+	if len(t.promoted) < len(latest.GameIDs)/2 {
+		return
+	}
+	next := BracketRound{}
+	for i := 0; i < len(latest.GameIDs); i += 2 {
+		g := t.addGameLocked(games)
+		for _, e := range t.promoted[i] {
+			player := g.AddPlayer(e.name)
+			t.seats[seatKey{gameID: e.gameID, playerID: e.playerID}] = seatKey{gameID: g.ID, playerID: player.ID}
+		}
+		next.GameIDs = append(next.GameIDs, g.ID)
+	}
+	t.Bracket = append(t.Bracket, next)
+	t.promoted = make(map[int][]entrant)
+}
+
+func (t *Tournament) addGameLocked(games *game.Store) *game.Game {
+	g := games.CreateGame(t.rounds, t.duration, t.lang)
+	t.Games = append(t.Games, g)
+	return g
+}
+
+// gameWinner reports the top scorer of a finished game. A game that has been
+// deleted counts as finished without a winner.
+func gameWinner(games *game.Store, id string, now time.Time) (entrant, bool) {
+	g, ok := games.GetGame(id)
+	if !ok {
+		return entrant{}, true
+	}
+	snap := g.Snapshot(now)
+	if snap.Status != game.StatusFinished {
+		return entrant{}, false
+	}
+	if len(snap.Scores) == 0 {
+		return entrant{}, true
+	}
+	top := snap.Scores[0]
+	return entrant{gameID: id, playerID: top.PlayerID, name: top.Name}, true
+}
+
+func newID() string {
+	buf := make([]byte, 10)
+	_, _ = rand.Read(buf)
+	encoder := base32.StdEncoding.WithPadding(base32.NoPadding)
+	return strings.ToLower(encoder.EncodeToString(buf))
+}
@@ -0,0 +1,202 @@
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"dagame/internal/game"
+)
+
+// finishWith has name win a one-round game outright.
+func finishWith(t *testing.T, games *game.Store, id, name string) {
+	t.Helper()
+	g, ok := games.GetGame(id)
+	if !ok {
+		t.Fatalf("game %s missing", id)
+	}
+	p := g.AddPlayer(name)
+	now := time.Now().UTC()
+	if err := g.Start(now); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if ok, err := g.SubmitGuess(p.ID, g.CurrentRoundData().Word, now); !ok || err != nil {
+		t.Fatalf("SubmitGuess: ok=%v err=%v", ok, err)
+	}
+	g.AdvanceIfNeeded(now.Add(time.Hour))
+	if !g.IsFinished() {
+		t.Fatalf("game %s did not finish", id)
+	}
+}
+
+func TestTournament_PromotesToChampion(t *testing.T) {
+	games := game.NewStore()
+	s := NewTournamentStore(games)
+	tour, err := s.Create(2, 1, time.Minute, "en")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, g := range tour.Games {
+			games.DeleteGame(g.ID)
+		}
+	})
+
+	opening := tour.Bracket[0].GameIDs
+	finishWith(t, games, opening[0], "alice")
+	s.Get(tour.ID)
+	if len(tour.Bracket) != 1 {
+		t.Fatal("final opened before both games finished")
+	}
+	finishWith(t, games, opening[1], "bob")
+	s.Get(tour.ID)
+	if len(tour.Bracket) != 2 || len(tour.Bracket[1].GameIDs) != 1 {
+		t.Fatalf("bracket %+v, want a single-game final", tour.Bracket)
+	}
+	if strings.Join(tour.Winners, ",") != "alice,bob" {
+		t.Errorf("Winners = %v", tour.Winners)
+	}
+	final, _ := games.GetGame(tour.Bracket[1].GameIDs[0])
+	if got := strings.Join(final.PlayerNames(), ","); got != "alice,bob" {
+		t.Errorf("final players = %q, want the opening winners", got)
+	}
+
+	finishWith(t, games, tour.Bracket[1].GameIDs[0], "alice")
+	s.Get(tour.ID)
+	if tour.Champion != "alice" {
+		t.Errorf("Champion = %q, want alice", tour.Champion)
+	}
+}
+
+func TestTournamentStore_Create_RejectsOddBracket(t *testing.T) {
+	s := NewTournamentStore(game.NewStore())
+	for _, n := range []int{0, 1, 3, 16} {
+		if _, err := s.Create(n, 1, time.Minute, "en"); err == nil {
+			t.Errorf("Create(%d games) should fail", n)
+		}
+	}
+}
+
+func TestTournamentStore_Limits(t *testing.T) {
+	games := game.NewStore()
+	s := NewTournamentStore(games)
+	t.Cleanup(func() { _ = games.Close() })
+	for i := 0; i < MaxTournaments; i++ {
+		if _, err := s.Create(2, 1, time.Minute, "en"); err != nil {
+			t.Fatalf("Create #%d: %v", i+1, err)
+		}
+	}
+	if _, err := s.Create(2, 1, time.Minute, "en"); !errors.Is(err, ErrTooManyTournaments) {
+		t.Fatalf("Create beyond MaxTournaments: err = %v", err)
+	}
+
+	s.deleteExpired(time.Hour, time.Now().UTC().Add(2*time.Hour))
+	if _, err := s.Create(2, 1, time.Minute, "en"); err != nil {
+		t.Errorf("Create after expiry sweep: %v", err)
+	}
+}
+
+func TestTournamentHandler_AdvanceSeatsWinner(t *testing.T) {
+	games := game.NewStore()
+	store := NewTournamentStore(games)
+	r := chi.NewRouter()
+	NewTournamentHandler(store).RegisterRoutes(r)
+	tour, err := store.Create(2, 1, time.Minute, "en")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, g := range tour.Games {
+			games.DeleteGame(g.ID)
+		}
+	})
+	opening := tour.Bracket[0].GameIDs
+	finishWith(t, games, opening[0], "alice")
+	finishWith(t, games, opening[1], "bob")
+
+	first, _ := games.GetGame(opening[0])
+	aliceID := first.Scores()[0].PlayerID
+	advance := func(playerID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tournaments/"+tour.ID+"/advance", strings.NewReader("game="+opening[0]))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "dagame_player_" + opening[0], Value: playerID})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := advance("someone-else"); rec.Code != http.StatusNotFound {
+		t.Errorf("advance by a non-winner: status %d, want 404", rec.Code)
+	}
+	rec := advance(aliceID)
+	finalID := tour.Bracket[1].GameIDs[0]
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/game/"+finalID {
+		t.Fatalf("advance: status %d location %q", rec.Code, rec.Header().Get("Location"))
+	}
+	final, _ := games.GetGame(finalID)
+	var seated bool
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "dagame_player_"+finalID {
+			name, _ := final.PlayerName(c.Value)
+			seated = name == "alice"
+		}
+	}
+	if !seated {
+		t.Error("winner was not given a cookie for their seat in the final")
+	}
+}
+
+func TestTournamentHandler_CreateRateLimited(t *testing.T) {
+	games := game.NewStore()
+	r := chi.NewRouter()
+	NewTournamentHandler(NewTournamentStore(games)).RegisterRoutes(r)
+	t.Cleanup(func() { _ = games.Close() })
+
+	var code int
+	for i := 0; i <= createBurst; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/tournaments", strings.NewReader("games=2&rounds=1"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		code = rec.Code
+	}
+	if code != http.StatusTooManyRequests {
+		t.Errorf("create past the burst: status %d, want 429", code)
+	}
+}
+
+func TestTournamentHandler_CreateAndBracket(t *testing.T) {
+	games := game.NewStore()
+	r := chi.NewRouter()
+	NewTournamentHandler(NewTournamentStore(games)).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/tournaments", strings.NewReader("games=4&rounds=1&duration=30"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("POST /tournaments status %d", rec.Code)
+	}
+	loc := rec.Header().Get("Location")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, loc+"/bracket", nil))
+	var body struct {
+		Rounds []BracketRound `json:"rounds"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode bracket: %v", err)
+	}
+	if len(body.Rounds) != 1 || len(body.Rounds[0].GameIDs) != 4 {
+		t.Errorf("bracket %+v, want one round of 4 games", body.Rounds)
+	}
+	if got := games.Stats().GamesByStatus[game.StatusLobby]; got != 4 {
+		t.Errorf("lobby games = %d, want 4", got)
+	}
+}
@@ -9,16 +9,23 @@ type LanguageOption struct {
 // GamePage holds data for the main game page template.
 type GamePage struct {
 	Title          string
+	Lang           string // game language, used for <html lang>
 	GameID         string
 	InviteURL      string
 	Players        []PlayerProgress
+	PlayerCount    int
+	SpectatorCount int
 	HasPlayer      bool
 	PlayerName     string
 	IsOwner        bool
+	IsSpectator    bool // joined to watch; no ready button or guess form
+	IsProtected    bool // join form asks for the room password
+	CanStart       bool // enough players have joined for the owner to start
+	Muted          bool
 	Rounds         int
 	RoundDuration  int
 	Status         string
-	ShowStart      bool
+	ShowReady      bool
 	Scores         []ScoreEntry
 	WinnerName     string
 	CurrentRound   int
@@ -27,6 +34,19 @@ type GamePage struct {
 	Scrambled      string
 	TargetWord     string
 	WordLength     int
+
+	EstimatedRemainingMin int // rounded up; 0 once the game is finished
+}
+
+// OverlayPage holds data for the read-only streaming overlay.
+type OverlayPage struct {
+	Lang         string
+	Status       string
+	CurrentRound int
+	TotalRounds  int
+	Scrambled    string
+	Scores       []ScoreEntry
+	WinnerName   string
 }
 
 // RoundFragment holds data for the round UI fragment.
@@ -40,38 +60,55 @@ type RoundFragment struct {
 	Scrambled      string
 	TargetWord     string
 	Expired        bool
-	RoundWinner    string
+	RoundWinner    string   // first solver, once the round has ended
+	RoundWinners   []string // everyone who solved the round so far, first solver first
 	RoundEndedMs   int64
 	NextRoundMs    int64
 	RoundLocked    bool
 	RoundKey       string
+	RevealedWord   string // answer shown when the round expired or the game ended
+	IsOwner        bool   // owner sees the lobby round controls
+	CanStart       bool   // enough players have joined for the owner to start
+	Muted          bool   // current player is muted; hide the guess form
+	Spectating     bool   // current player only watches; hide the guess form
+	Solved         bool   // current player solved a round that is still open to others
 }
 
 // ScoreEntry holds a player's score for rendering.
 type ScoreEntry struct {
+	Handle string // public player handle; never the session ID
 	Name   string
 	Points int
+	Delta  int    // points earned in the current or last round
+	Color  string // CSS hex colour for the player's name; empty for none
 }
 
 // ScoresFragment holds data for the scores panel.
 type ScoresFragment struct {
-	GameID     string
-	Scores     []ScoreEntry
-	WinnerName string
-	Status     string
-	IsOwner    bool
-	PlayerName string // current player, shown in bold in the list
+	GameID       string
+	Scores       []ScoreEntry
+	WinnerName   string
+	Status       string
+	IsOwner      bool
+	PlayerName   string // current player, shown in bold in the list
+	PlayerHandle string // current player's handle, left out of the new-owner list
 }
 
 // PlayerProgress holds a player's correct-letter progress.
 type PlayerProgress struct {
 	Name    string
 	Correct int
+	Ready   bool
+	Muted   bool
+	Color   string // CSS hex colour for the player's name; empty for none
 }
 
 // PlayersFragment holds data for the players panel.
 type PlayersFragment struct {
-	Players    []PlayerProgress
-	WordLength int
-	PlayerName string
+	Players        []PlayerProgress
+	WordLength     int
+	PlayerName     string
+	InLobby        bool // show ready marks instead of progress
+	PlayerCount    int  // players in the game, including the viewer
+	SpectatorCount int
 }
@@ -0,0 +1,43 @@
+// Package httplog provides request logging middleware shared by the servers.
+package httplog
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// GameIDLogger logs one structured line per request through logger, tagged
+// with the chi {id} URL parameter as "gameID" so a single game's traffic can
+// be filtered out of the server logs. chi resolves URL params while routing,
+// after top-level middleware has run, so the parameter is read once the
+// handler returns. Requests outside a game route omit the attribute.
+func GameIDLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r)
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", ww.Status()),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if id := middleware.GetReqID(r.Context()); id != "" {
+				attrs = append(attrs, slog.String("requestID", id))
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if gameID := rctx.URLParam("id"); gameID != "" {
+					attrs = append(attrs, slog.String("gameID", gameID))
+				}
+			}
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "request", attrs...)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package httplog
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestGameIDLogger(t *testing.T) {
+	var buf bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(GameIDLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	r.Get("/game/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/game/abc123", nil))
+	line := buf.String()
+	if !strings.Contains(line, "gameID=abc123") || !strings.Contains(line, "status=418") {
+		t.Errorf("game request log = %q, want gameID and status", line)
+	}
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if line := buf.String(); strings.Contains(line, "gameID") {
+		t.Errorf("home request log = %q, want no gameID", line)
+	}
+}
@@ -0,0 +1,120 @@
+// Package ratelimit provides a small token-bucket limiter shared by the game
+// handlers.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Guess limits per player and game, shared by both games. The burst covers a
+// quick flurry of attempts; anything faster is almost certainly a script.
+const (
+	GuessesPerSecond = 5
+	GuessBurst       = 5
+)
+
+// Bucket is a token bucket that refills at a fixed rate up to burst tokens.
+// It starts full. The zero value allows nothing; use NewBucket.
+type Bucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket returns a full bucket that refills at rate tokens per second and
+// holds at most burst tokens.
+func NewBucket(rate float64, burst int) *Bucket {
+	return &Bucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Allow takes one token at now. When the bucket is empty it returns false and
+// how long until the next token is available.
+func (b *Bucket) Allow(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	if b.last.IsZero() || now.After(b.last) {
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	if b.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	return false, wait
+}
+
+// RetryAfterSeconds rounds wait up to whole seconds for a Retry-After header,
+// never returning less than one.
+func RetryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
+
+// Keyed holds one Bucket per key within a group, such as per player within a
+// game, so a whole group can be dropped at once.
+type Keyed struct {
+	rate  float64
+	burst int
+
+	mu     sync.Mutex
+	groups map[string]map[string]*Bucket
+}
+
+// NewKeyed returns a Keyed whose buckets each refill at rate tokens per second
+// up to burst.
+func NewKeyed(rate float64, burst int) *Keyed {
+	return &Keyed{rate: rate, burst: burst, groups: make(map[string]map[string]*Bucket)}
+}
+
+// Allow takes a token from the bucket for key in group at now, creating a full
+// bucket on first use. It reports like Bucket.Allow.
+func (k *Keyed) Allow(group, key string, now time.Time) (bool, time.Duration) {
+	k.mu.Lock()
+	buckets, ok := k.groups[group]
+	if !ok {
+		buckets = make(map[string]*Bucket)
+		k.groups[group] = buckets
+	}
+	b, ok := buckets[key]
+	if !ok {
+		b = NewBucket(k.rate, k.burst)
+		buckets[key] = b
+	}
+	k.mu.Unlock()
+	return b.Allow(now)
+}
+
+// Clear drops every bucket in group.
+func (k *Keyed) Clear(group string) {
+	k.mu.Lock()
+	delete(k.groups, group)
+	k.mu.Unlock()
+}
+
+// Len returns how many buckets are held across all groups.
+func (k *Keyed) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	n := 0
+	for _, buckets := range k.groups {
+		n += len(buckets)
+	}
+	return n
+}
+
+// TooManyRequests writes a 429 with message and a Retry-After header for wait.
+func TooManyRequests(w http.ResponseWriter, wait time.Duration, message string) {
+	w.Header().Set("Retry-After", strconv.Itoa(RetryAfterSeconds(wait)))
+	http.Error(w, message, http.StatusTooManyRequests)
+}
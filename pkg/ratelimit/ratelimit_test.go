@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBucket_Allow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := NewBucket(5, 3)
+	for i := range 3 {
+		if ok, _ := b.Allow(now); !ok {
+			t.Fatalf("request %d: want allowed while bucket has tokens", i+1)
+		}
+	}
+	ok, wait := b.Allow(now)
+	if ok {
+		t.Fatal("want empty bucket to reject")
+	}
+	if wait != 200*time.Millisecond {
+		t.Fatalf("wait = %v, want 200ms", wait)
+	}
+	if ok, _ := b.Allow(now.Add(200 * time.Millisecond)); !ok {
+		t.Fatal("want one token refilled after 200ms")
+	}
+	if ok, _ := b.Allow(now.Add(200 * time.Millisecond)); ok {
+		t.Fatal("want bucket empty again")
+	}
+	// Refill is capped at burst.
+	later := now.Add(time.Hour)
+	for i := range 3 {
+		if ok, _ := b.Allow(later); !ok {
+			t.Fatalf("request %d after idle: want allowed", i+1)
+		}
+	}
+	if ok, _ := b.Allow(later); ok {
+		t.Fatal("want refill capped at burst")
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		wait time.Duration
+		want int
+	}{
+		{0, 1},
+		{200 * time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+	}
+	for _, tt := range tests {
+		if got := RetryAfterSeconds(tt.wait); got != tt.want {
+			t.Errorf("RetryAfterSeconds(%v) = %d, want %d", tt.wait, got, tt.want)
+		}
+	}
+}
+
+func TestKeyed_AllowAndClear(t *testing.T) {
+	now := time.Unix(1000, 0)
+	k := NewKeyed(1, 1)
+	if ok, _ := k.Allow("g1", "alice", now); !ok {
+		t.Fatal("first request should be allowed")
+	}
+	if ok, _ := k.Allow("g1", "alice", now); ok {
+		t.Fatal("alice's bucket should be empty")
+	}
+	if ok, _ := k.Allow("g1", "bob", now); !ok {
+		t.Error("bob has his own bucket")
+	}
+	if ok, _ := k.Allow("g2", "alice", now); !ok {
+		t.Error("alice has a separate bucket in another group")
+	}
+	if n := k.Len(); n != 3 {
+		t.Errorf("Len = %d, want 3", n)
+	}
+
+	k.Clear("g1")
+	if n := k.Len(); n != 1 {
+		t.Errorf("Len after Clear = %d, want 1", n)
+	}
+	if ok, _ := k.Allow("g1", "alice", now); !ok {
+		t.Error("alice should start with a full bucket after Clear")
+	}
+	if ok, _ := k.Allow("g2", "alice", now); ok {
+		t.Error("Clear(g1) must not refill g2")
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	rec := httptest.NewRecorder()
+	TooManyRequests(rec, 1500*time.Millisecond, "slow down")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After %q, want 2", got)
+	}
+}
@@ -1,29 +1,74 @@
 package realtime
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCoalesceWindow is how long NewBroadcaster buffers events before flushing.
+const DefaultCoalesceWindow = 20 * time.Millisecond
+
+// BroadcasterOptions configures a Broadcaster.
+type BroadcasterOptions struct {
+	// CoalesceWindow buffers published events for this long and delivers each
+	// distinct event name once per window. Zero delivers every event immediately.
+	CoalesceWindow time.Duration
+}
 
 // Broadcaster publishes lightweight events to SSE subscribers.
 type Broadcaster struct {
 	mu   sync.Mutex
-	subs map[chan string]struct{}
+	subs map[chan string]func(string) bool // subscriber -> filter; nil receives every event
+
+	window   time.Duration
+	pending  []string // events waiting for the next flush, in publish order
+	flush    *time.Timer
+	draining bool // set by Drain; Publish drops events from then on
 }
 
-// NewBroadcaster creates an empty broadcaster.
+// NewBroadcaster creates an empty broadcaster that coalesces events over DefaultCoalesceWindow.
 func NewBroadcaster() *Broadcaster {
+	return NewBroadcasterWithOptions(BroadcasterOptions{CoalesceWindow: DefaultCoalesceWindow})
+}
+
+// NewBroadcasterWithOptions creates an empty broadcaster with the given options.
+func NewBroadcasterWithOptions(opts BroadcasterOptions) *Broadcaster {
 	return &Broadcaster{
-		subs: make(map[chan string]struct{}),
+		subs:   make(map[chan string]func(string) bool),
+		window: opts.CoalesceWindow,
 	}
 }
 
 // Subscribe registers a new subscriber and returns its event channel.
 func (b *Broadcaster) Subscribe() chan string {
+	return b.SubscribeFiltered(nil)
+}
+
+// SubscribeFiltered registers a subscriber that only receives events for which
+// filter returns true. A nil filter receives everything, like Subscribe.
+func (b *Broadcaster) SubscribeFiltered(filter func(string) bool) chan string {
 	ch := make(chan string, 10)
 	b.mu.Lock()
-	b.subs[ch] = struct{}{}
+	b.subs[ch] = filter
 	b.mu.Unlock()
 	return ch
 }
 
+// SubscribeWithFilter registers a subscriber that only receives the named
+// events, so it is not woken for events it would discard. With no names it
+// receives everything, like Subscribe.
+func (b *Broadcaster) SubscribeWithFilter(events ...string) chan string {
+	if len(events) == 0 {
+		return b.Subscribe()
+	}
+	allowed := make(map[string]bool, len(events))
+	for _, e := range events {
+		allowed[e] = true
+	}
+	return b.SubscribeFiltered(func(event string) bool { return allowed[event] })
+}
+
 // Unsubscribe removes a subscriber and closes its channel.
 func (b *Broadcaster) Unsubscribe(ch chan string) {
 	b.mu.Lock()
@@ -34,15 +79,118 @@ func (b *Broadcaster) Unsubscribe(ch chan string) {
 	b.mu.Unlock()
 }
 
-// Publish delivers an event to all subscribers.
+// SubscriberCount returns how many subscribers are currently registered.
+func (b *Broadcaster) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Close closes every subscriber channel and drops pending events. Subscribers
+// should treat a closed channel as the end of the stream.
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+	if b.flush != nil {
+		b.flush.Stop()
+		b.flush = nil
+	}
+	b.pending = nil
+}
+
+// Publish delivers an event to all subscribers. With a coalescing window the
+// event is queued, and duplicates published before the flush are dropped.
 func (b *Broadcaster) Publish(event string) {
 	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.draining {
+		return
+	}
+	if b.window <= 0 {
+		b.deliverLocked(event)
+		return
+	}
+	for _, e := range b.pending {
+		if e == event {
+			return
+		}
+	}
+	b.pending = append(b.pending, event)
+	if b.flush == nil {
+		b.flush = time.AfterFunc(b.window, b.flushPending)
+	}
+}
+
+// drainPollInterval is how often Drain checks whether subscribers caught up.
+const drainPollInterval = 10 * time.Millisecond
+
+// Drain prepares for shutdown: it stops accepting Publish calls, delivers any
+// coalesced events still pending, then waits until every subscriber has read
+// its buffered events (or unsubscribed). It returns ctx.Err() if ctx ends first.
+func (b *Broadcaster) Drain(ctx context.Context) error {
+	b.mu.Lock()
+	b.draining = true
+	if b.flush != nil {
+		b.flush.Stop()
+		b.flush = nil
+	}
+	for _, event := range b.pending {
+		b.deliverLocked(event)
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		if b.drained() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// drained reports whether every subscriber channel is empty.
+func (b *Broadcaster) drained() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	for ch := range b.subs {
+		if len(ch) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// flushPending delivers the queued events once each.
+func (b *Broadcaster) flushPending() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range b.pending {
+		b.deliverLocked(event)
+	}
+	b.pending = nil
+	b.flush = nil
+}
+
+// deliverLocked sends event to every subscriber. Must be called with b.mu held.
+func (b *Broadcaster) deliverLocked(event string) {
+	for ch, filter := range b.subs {
+		if filter != nil && !filter(event) {
+			continue
+		}
 		select {
 		case ch <- event:
 		default:
 			// Drop if the subscriber is lagging; next event will catch it up.
 		}
 	}
-	b.mu.Unlock()
 }
@@ -1,7 +1,10 @@
 package realtime
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestNewBroadcaster(t *testing.T) {
@@ -69,3 +72,194 @@ func TestBroadcaster_UnsubscribeRemovesFromDelivery(t *testing.T) {
 	}
 	b.Unsubscribe(ch2)
 }
+
+func TestBroadcaster_SubscriberCount(t *testing.T) {
+	b := NewBroadcaster()
+	if n := b.SubscriberCount(); n != 0 {
+		t.Fatalf("SubscriberCount = %d, want 0", n)
+	}
+	ch1 := b.Subscribe()
+	ch2 := b.SubscribeFiltered(func(string) bool { return true })
+	if n := b.SubscriberCount(); n != 2 {
+		t.Errorf("SubscriberCount after two subscribes = %d, want 2", n)
+	}
+	b.Unsubscribe(ch1)
+	b.Unsubscribe(ch1) // repeated unsubscribe must not double-count
+	if n := b.SubscriberCount(); n != 1 {
+		t.Errorf("SubscriberCount after unsubscribe = %d, want 1", n)
+	}
+	b.Unsubscribe(ch2)
+	if n := b.SubscriberCount(); n != 0 {
+		t.Errorf("SubscriberCount after unsubscribing all = %d, want 0", n)
+	}
+}
+
+func TestBroadcaster_CoalescesDuplicateEvents(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{CoalesceWindow: 20 * time.Millisecond})
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+
+	b.Publish("round")
+	b.Publish("scores")
+	b.Publish("round")
+	b.Publish("players")
+	b.Publish("scores")
+
+	want := []string{"round", "scores", "players"}
+	for _, w := range want {
+		if got := <-ch; got != w {
+			t.Errorf("got event %q, want %q", got, w)
+		}
+	}
+	select {
+	case got := <-ch:
+		t.Errorf("unexpected extra event %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBroadcaster_ZeroWindowDeliversImmediately(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{})
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+
+	b.Publish("round")
+	b.Publish("round")
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-ch:
+			if got != "round" {
+				t.Errorf("got event %q, want round", got)
+			}
+		default:
+			t.Fatalf("event %d not delivered synchronously", i)
+		}
+	}
+}
+
+func TestBroadcaster_SubscribeFiltered(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{})
+	ch := b.SubscribeFiltered(func(event string) bool { return event == "canvas" || event == "round" })
+	defer b.Unsubscribe(ch)
+	all := b.Subscribe()
+	defer b.Unsubscribe(all)
+
+	b.Publish("scores")
+	b.Publish("canvas")
+	b.Publish("players")
+	b.Publish("round")
+
+	for _, want := range []string{"canvas", "round"} {
+		if got := <-ch; got != want {
+			t.Errorf("filtered got %q, want %q", got, want)
+		}
+	}
+	select {
+	case got := <-ch:
+		t.Errorf("filtered subscriber received %q", got)
+	default:
+	}
+	if len(all) != 4 {
+		t.Errorf("unfiltered subscriber has %d events, want 4", len(all))
+	}
+}
+
+func TestBroadcaster_DrainDeliversInFlightEvents(t *testing.T) {
+	b := NewBroadcaster()
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+
+	want := []string{"round", "players", "scores", "timer", "canvas"}
+	for _, e := range want {
+		b.Publish(e) // still pending in the coalescing window
+	}
+	done := make(chan error, 1)
+	go func() { done <- b.Drain(context.Background()) }()
+
+	for i, w := range want {
+		select {
+		case got := <-ch:
+			if got != w {
+				t.Errorf("event %d = %q, want %q", i, got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Drain = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Drain did not return after subscribers caught up")
+	}
+
+	b.Publish("round")
+	select {
+	case e := <-ch:
+		t.Errorf("got %q published after Drain", e)
+	case <-time.After(3 * DefaultCoalesceWindow):
+	}
+}
+
+func TestBroadcaster_DrainHonoursContext(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{})
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+	b.Publish("round") // never read
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := b.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Drain = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestBroadcaster_SubscribeWithFilter(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{})
+	hints := b.SubscribeWithFilter("wordhint")
+	defer b.Unsubscribe(hints)
+	some := b.SubscribeWithFilter("round", "scores")
+	defer b.Unsubscribe(some)
+	all := b.SubscribeWithFilter()
+	defer b.Unsubscribe(all)
+
+	for _, e := range []string{"canvas", "round", "wordhint", "players", "scores", "wordhint"} {
+		b.Publish(e)
+	}
+
+	drain := func(ch chan string) []string {
+		var got []string
+		for {
+			select {
+			case e := <-ch:
+				got = append(got, e)
+			default:
+				return got
+			}
+		}
+	}
+	tests := []struct {
+		name string
+		ch   chan string
+		want []string
+	}{
+		{"wordhint only", hints, []string{"wordhint", "wordhint"}},
+		{"round and scores", some, []string{"round", "scores"}},
+		{"no names", all, []string{"canvas", "round", "wordhint", "players", "scores", "wordhint"}},
+	}
+	for _, tt := range tests {
+		got := drain(tt.ch)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package realtime
+
+// SnapshotDiffer remembers the last HTML sent for each event on one stream so
+// unchanged fragments can be skipped instead of re-sent. Give each stream its
+// own differ; it is not safe for concurrent use and is dropped with the stream.
+type SnapshotDiffer struct {
+	last map[string]string // event -> last HTML sent
+}
+
+// Changed records html for event and reports whether it differs from what was
+// last recorded. The first call for an event always reports true.
+func (d *SnapshotDiffer) Changed(event, html string) bool {
+	prev, ok := d.last[event]
+	if ok && prev == html {
+		return false
+	}
+	if d.last == nil {
+		d.last = make(map[string]string)
+	}
+	d.last[event] = html
+	return true
+}
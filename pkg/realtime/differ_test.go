@@ -0,0 +1,52 @@
+package realtime
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotDiffer_Changed(t *testing.T) {
+	var d SnapshotDiffer
+	if !d.Changed("scores", "<ul>a</ul>") {
+		t.Error("first render should count as changed")
+	}
+	if d.Changed("scores", "<ul>a</ul>") {
+		t.Error("identical render should be skipped")
+	}
+	if !d.Changed("scores", "<ul>b</ul>") {
+		t.Error("different render should count as changed")
+	}
+	if !d.Changed("players", "<ul>b</ul>") {
+		t.Error("another event should get its own first render")
+	}
+	var other SnapshotDiffer
+	if !other.Changed("scores", "<ul>b</ul>") {
+		t.Error("another stream should get its own first render")
+	}
+}
+
+// BenchmarkSSE_DiffVsAlways replays a game's events where the scores fragment
+// only changes once every ten publishes and reports the bytes sent per event.
+func BenchmarkSSE_DiffVsAlways(b *testing.B) {
+	scores := func(version int) string {
+		return "<ul>" + strings.Repeat("<li>player: "+strconv.Itoa(version)+"</li>", 8) + "</ul>"
+	}
+	run := func(b *testing.B, send func(html string) bool) {
+		sent := 0
+		for i := 0; i < b.N; i++ {
+			html := scores(i / 10)
+			if send(html) {
+				sent += len(html)
+			}
+		}
+		b.ReportMetric(float64(sent)/float64(b.N), "sent-bytes/event")
+	}
+	b.Run("always", func(b *testing.B) {
+		run(b, func(string) bool { return true })
+	})
+	b.Run("diff", func(b *testing.B) {
+		var d SnapshotDiffer
+		run(b, func(html string) bool { return d.Changed("scores", html) })
+	})
+}
@@ -0,0 +1,114 @@
+package realtime
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultHistorySize is how many events a room's HistoryBroadcaster keeps.
+const DefaultHistorySize = 20
+
+// StoredEvent is a published event and its sequence number.
+type StoredEvent struct {
+	ID    int64
+	Event string
+}
+
+// HistoryBroadcaster is a Broadcaster that also remembers the last few events
+// it published, numbered from 1, so a reconnecting SSE client can send its
+// Last-Event-ID and catch up on what it missed.
+type HistoryBroadcaster struct {
+	*Broadcaster
+
+	mu     sync.Mutex
+	size   int
+	lastID int64
+	events []StoredEvent // oldest first, at most size entries
+}
+
+// NewHistoryBroadcaster wraps b, keeping the last size events (DefaultHistorySize
+// if size < 1).
+func NewHistoryBroadcaster(b *Broadcaster, size int) *HistoryBroadcaster {
+	if size < 1 {
+		size = DefaultHistorySize
+	}
+	return &HistoryBroadcaster{Broadcaster: b, size: size}
+}
+
+// Publish records event in the history, then delivers it like Broadcaster.Publish.
+func (h *HistoryBroadcaster) Publish(event string) {
+	h.mu.Lock()
+	h.lastID++
+	if len(h.events) == h.size {
+		h.events = append(h.events[:0], h.events[1:]...)
+	}
+	h.events = append(h.events, StoredEvent{ID: h.lastID, Event: event})
+	h.mu.Unlock()
+	h.Broadcaster.Publish(event)
+}
+
+// LastID returns the ID of the most recent event, or 0 if none was published.
+func (h *HistoryBroadcaster) LastID() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastID
+}
+
+// EventsSince returns the remembered events with an ID greater than id, oldest
+// first. Use Covers to tell whether older ones were already dropped.
+func (h *HistoryBroadcaster) EventsSince(id int64) []StoredEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var out []StoredEvent
+	for _, e := range h.events {
+		if e.ID > id {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Covers reports whether every event after id is still in the history, so
+// EventsSince(id) is complete. An id from the future (e.g. issued before a
+// server restart) is not covered.
+func (h *HistoryBroadcaster) Covers(id int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if id < 0 || id > h.lastID {
+		return false
+	}
+	if id == h.lastID {
+		return true
+	}
+	return len(h.events) > 0 && h.events[0].ID <= id+1
+}
+
+// EventNames returns the distinct event names in events, in first-seen order.
+// Streams render current state per event name, so replaying each once is enough.
+func EventNames(events []StoredEvent) []string {
+	var names []string
+	seen := make(map[string]bool, len(events))
+	for _, e := range events {
+		if !seen[e.Event] {
+			seen[e.Event] = true
+			names = append(names, e.Event)
+		}
+	}
+	return names
+}
+
+// LastEventID parses the SSE Last-Event-ID header a browser sends when it
+// reconnects.
+func LastEventID(r *http.Request) (int64, bool) {
+	v := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if v == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+	return id, true
+}
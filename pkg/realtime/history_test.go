@@ -0,0 +1,119 @@
+package realtime
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHistoryBroadcaster_PublishAssignsIncreasingIDs(t *testing.T) {
+	h := NewHistoryBroadcaster(NewBroadcaster(), 5)
+	if h.LastID() != 0 {
+		t.Fatalf("LastID = %d before any publish, want 0", h.LastID())
+	}
+	h.Publish("round")
+	h.Publish("players")
+	want := []StoredEvent{{ID: 1, Event: "round"}, {ID: 2, Event: "players"}}
+	if got := h.EventsSince(0); !reflect.DeepEqual(got, want) {
+		t.Errorf("EventsSince(0) = %v, want %v", got, want)
+	}
+	if h.LastID() != 2 {
+		t.Errorf("LastID = %d, want 2", h.LastID())
+	}
+}
+
+func TestHistoryBroadcaster_DropsOldest(t *testing.T) {
+	h := NewHistoryBroadcaster(NewBroadcaster(), 3)
+	for _, e := range []string{"a", "b", "c", "d", "e"} {
+		h.Publish(e)
+	}
+	want := []StoredEvent{{ID: 3, Event: "c"}, {ID: 4, Event: "d"}, {ID: 5, Event: "e"}}
+	if got := h.EventsSince(0); !reflect.DeepEqual(got, want) {
+		t.Errorf("EventsSince(0) = %v, want %v", got, want)
+	}
+	tests := []struct {
+		id   int64
+		want bool
+	}{
+		{0, false}, // events 1 and 2 are gone
+		{1, false},
+		{2, true},
+		{5, true},
+		{6, false}, // from before a restart
+		{-1, false},
+	}
+	for _, tt := range tests {
+		if got := h.Covers(tt.id); got != tt.want {
+			t.Errorf("Covers(%d) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryBroadcaster_DefaultSize(t *testing.T) {
+	h := NewHistoryBroadcaster(NewBroadcaster(), 0)
+	for i := 0; i < DefaultHistorySize+5; i++ {
+		h.Publish("round")
+	}
+	if got := len(h.EventsSince(0)); got != DefaultHistorySize {
+		t.Errorf("kept %d events, want %d", got, DefaultHistorySize)
+	}
+}
+
+func TestHistoryBroadcaster_ReconnectReplaysGap(t *testing.T) {
+	h := NewHistoryBroadcaster(NewBroadcasterWithOptions(BroadcasterOptions{}), DefaultHistorySize)
+	sub := h.Subscribe()
+	h.Publish("round")
+	<-sub
+	seen := h.LastID()
+	h.Unsubscribe(sub) // client drops
+
+	gap := []string{"players", "scores", "players"}
+	for _, e := range gap {
+		h.Publish(e)
+	}
+
+	sub = h.Subscribe() // client reconnects with Last-Event-ID: seen
+	defer h.Unsubscribe(sub)
+	if !h.Covers(seen) {
+		t.Fatalf("Covers(%d) = false, want true", seen)
+	}
+	var replayed []string
+	for _, e := range h.EventsSince(seen) {
+		replayed = append(replayed, e.Event)
+	}
+	if !reflect.DeepEqual(replayed, gap) {
+		t.Errorf("replayed %v, want %v", replayed, gap)
+	}
+	if got, want := EventNames(h.EventsSince(seen)), []string{"players", "scores"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EventNames = %v, want %v", got, want)
+	}
+
+	h.Publish("round")
+	if got := <-sub; got != "round" {
+		t.Errorf("live event after replay = %q, want round", got)
+	}
+}
+
+func TestLastEventID(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int64
+		ok     bool
+	}{
+		{"", 0, false},
+		{"42", 42, true},
+		{" 7 ", 7, true},
+		{"abc", 0, false},
+		{"-3", 0, false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/stream", nil)
+		if tt.header != "" {
+			req.Header.Set("Last-Event-ID", tt.header)
+		}
+		got, ok := LastEventID(req)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LastEventID(%q) = %d, %v; want %d, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package realtime
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// EventSink delivers named events to one stream client, over SSE or WebSocket.
+type EventSink interface {
+	Send(event, data string)
+	Retry() // sets the browser's reconnect delay; SSE only
+	EventID(id int64)
+	KeepAlive()
+	Flush()
+	Closed() <-chan struct{}
+	Close()
+}
+
+// OpenEventSink picks the transport: WebSocket for upgrade requests (some
+// proxies buffer SSE), SSE when the client accepts text/event-stream or sends
+// no Accept header, otherwise 406. On failure a response has been written.
+func OpenEventSink(w http.ResponseWriter, r *http.Request) (EventSink, bool) {
+	switch {
+	case IsWebSocketUpgrade(r):
+		conn, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			slog.Warn("websocket upgrade", slog.Any("err", err))
+			return nil, false
+		}
+		return wsSink{conn: conn}, true
+	case acceptsEventStream(r):
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return nil, false
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		return sseSink{w: w, flusher: flusher}, true
+	default:
+		http.Error(w, "stream requires Accept: text/event-stream or a WebSocket upgrade", http.StatusNotAcceptable)
+		return nil, false
+	}
+}
+
+// acceptsEventStream reports whether r's Accept header allows an SSE response.
+// A missing header accepts anything.
+func acceptsEventStream(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return accept == "" ||
+		strings.Contains(accept, "text/event-stream") ||
+		strings.Contains(accept, "*/*")
+}
+
+type sseSink struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (s sseSink) Send(event, data string) { writeSSE(s.w, event, data) }
+func (s sseSink) Retry()                  { writeSSERetry(s.w, sseRetryMs) }
+func (s sseSink) EventID(id int64)        { writeSSEID(s.w, id) }
+func (s sseSink) KeepAlive()              { _, _ = s.w.Write([]byte(": keepalive\n\n")) }
+func (s sseSink) Flush()                  { s.flusher.Flush() }
+func (s sseSink) Closed() <-chan struct{} { return nil } // request context covers disconnects
+func (s sseSink) Close()                  {}
+
+type wsSink struct {
+	conn *WebSocketConn
+}
+
+// Write errors are ignored: a broken connection closes conn.Done(), which ends the stream.
+func (s wsSink) Send(event, data string) { _ = s.conn.WriteEvent(event, data) }
+func (s wsSink) Retry()                  {}
+func (s wsSink) EventID(int64)           {} // WebSocket clients resync from the full burst
+func (s wsSink) KeepAlive()              { _ = s.conn.Ping() }
+func (s wsSink) Flush()                  {}
+func (s wsSink) Closed() <-chan struct{} { return s.conn.Done() }
+func (s wsSink) Close()                  { _ = s.conn.Close() }
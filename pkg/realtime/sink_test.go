@@ -0,0 +1,52 @@
+package realtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenEventSink_Accept(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		wantOK bool
+	}{
+		{"event stream", "text/event-stream", true},
+		{"no accept header", "", true},
+		{"wildcard", "*/*", true},
+		{"json only", "application/json", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			out, ok := OpenEventSink(rec, req)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if rec.Code != http.StatusNotAcceptable {
+					t.Errorf("status %d, want 406", rec.Code)
+				}
+				return
+			}
+			out.Retry()
+			out.Send("round", "<p>hi</p>")
+			out.Flush()
+			if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if !strings.HasPrefix(rec.Body.String(), "retry: 2000\n\n") {
+				t.Errorf("stream does not start with the retry delay: %q", rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), "event: round\ndata: <p>hi</p>\n\n") {
+				t.Errorf("body = %q", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sseRetryMs is the reconnect delay sent to browsers on every new SSE stream.
+const sseRetryMs = 2000
+
+// sseLineEndings folds CRLF and lone CR to LF; SSE treats CR as a line break,
+// so a stray one would split or end the event early.
+var sseLineEndings = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// writeSSE writes one named event, splitting data into one data line per line.
+func writeSSE(w http.ResponseWriter, event, data string) {
+	_, _ = w.Write([]byte("event: " + event + "\n"))
+	for _, line := range strings.Split(sseLineEndings.Replace(data), "\n") {
+		_, _ = w.Write([]byte("data: " + line + "\n"))
+	}
+	_, _ = w.Write([]byte("\n"))
+}
+
+// writeSSERetry sets the browser's reconnect delay for this stream.
+func writeSSERetry(w http.ResponseWriter, ms int) {
+	_, _ = w.Write([]byte("retry: " + strconv.Itoa(ms) + "\n\n"))
+}
+
+// writeSSEID records the latest history ID; the browser echoes it back as
+// Last-Event-ID when it reconnects.
+func writeSSEID(w http.ResponseWriter, id int64) {
+	_, _ = w.Write([]byte("id: " + strconv.FormatInt(id, 10) + "\n\n"))
+}
+
+// Heartbeat is the keep-alive interval for open streams, read once from
+// SSE_HEARTBEAT_SECONDS at startup.
+var Heartbeat = ParseHeartbeat(os.Getenv("SSE_HEARTBEAT_SECONDS"))
+
+// ParseHeartbeat turns a seconds value into a keep-alive interval (default 25s,
+// clamped to 5–55s so idle-timeout proxies keep the stream open).
+func ParseHeartbeat(value string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		secs = 25
+	}
+	secs = min(max(secs, 5), 55)
+	return time.Duration(secs) * time.Second
+}
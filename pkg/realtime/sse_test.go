@@ -0,0 +1,39 @@
+package realtime
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteSSE_CarriageReturn(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeSSE(rec, "round", "<p>a</p>\r\n<p>b</p>\r<p>c</p>")
+	body := rec.Body.String()
+	if strings.Contains(body, "\r") {
+		t.Errorf("output contains CR: %q", body)
+	}
+	want := "event: round\ndata: <p>a</p>\ndata: <p>b</p>\ndata: <p>c</p>\n\n"
+	if body != want {
+		t.Errorf("got %q, want %q", body, want)
+	}
+}
+
+func TestParseHeartbeat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 25 * time.Second},
+		{"junk", 25 * time.Second},
+		{" 30 ", 30 * time.Second},
+		{"1", 5 * time.Second},
+		{"600", 55 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := ParseHeartbeat(tt.value); got != tt.want {
+			t.Errorf("ParseHeartbeat(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package realtime
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -10,7 +13,16 @@ import (
 type Room[T any] struct {
 	ID    string
 	State T
-	hub   *Broadcaster
+	hub   *HistoryBroadcaster
+
+	ctx    context.Context // cancelled when the room is deleted
+	cancel context.CancelFunc
+}
+
+// newRoom creates a room with a fresh broadcaster and context.
+func newRoom[T any](id string, state T) *Room[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Room[T]{ID: id, State: state, hub: newRoomHub(), ctx: ctx, cancel: cancel}
 }
 
 // RoomStore manages rooms and their broadcasters.
@@ -19,6 +31,7 @@ type RoomStore[T any] struct {
 	rooms map[string]*Room[T]
 	loops map[string]context.CancelFunc
 	wakes map[string]chan struct{}
+	dones map[string]chan struct{} // closed when the room's loop goroutine exits
 }
 
 // NewRoomStore creates an empty room store.
@@ -27,6 +40,7 @@ func NewRoomStore[T any]() *RoomStore[T] {
 		rooms: make(map[string]*Room[T]),
 		loops: make(map[string]context.CancelFunc),
 		wakes: make(map[string]chan struct{}),
+		dones: make(map[string]chan struct{}),
 	}
 }
 
@@ -34,11 +48,25 @@ func NewRoomStore[T any]() *RoomStore[T] {
 func (s *RoomStore[T]) Create(id string, state T) *Room[T] {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	r := &Room[T]{ID: id, State: state, hub: NewBroadcaster()}
+	r := newRoom(id, state)
 	s.rooms[id] = r
 	return r
 }
 
+// Context returns a context that is cancelled when the room is deleted, for
+// goroutines that act on the room's behalf. It is already cancelled if id is unknown.
+func (s *RoomStore[T]) Context(id string) context.Context {
+	s.mu.RLock()
+	r, ok := s.rooms[id]
+	s.mu.RUnlock()
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	return r.ctx
+}
+
 // Get returns the room by ID if it exists.
 func (s *RoomStore[T]) Get(id string) (*Room[T], bool) {
 	s.mu.RLock()
@@ -47,34 +75,202 @@ func (s *RoomStore[T]) Get(id string) (*Room[T], bool) {
 	return r, ok
 }
 
-// Publish notifies subscribers of the room's broadcaster.
+// Rooms returns a snapshot of every room in the store, in no particular order.
+func (s *RoomStore[T]) Rooms() []*Room[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rooms := make([]*Room[T], 0, len(s.rooms))
+	for _, r := range s.rooms {
+		rooms = append(rooms, r)
+	}
+	return rooms
+}
+
+// LoopCount returns how many room loops started by RunLoop are still running.
+func (s *RoomStore[T]) LoopCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.loops)
+}
+
+// LoopDone returns a channel that is closed once the room's loop has exited.
+// It is already closed if no loop is running for id.
+func (s *RoomStore[T]) LoopDone(id string) <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if done, ok := s.dones[id]; ok {
+		return done
+	}
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Subscribers returns how many streams are subscribed to the room's broadcaster.
+func (r *Room[T]) Subscribers() int {
+	if r.hub == nil {
+		return 0
+	}
+	return r.hub.SubscriberCount()
+}
+
+// Publish notifies subscribers of the room's broadcaster. Publishing to a
+// deleted or unknown room is a no-op rather than resurrecting it.
 func (s *RoomStore[T]) Publish(id string, event string) {
-	hub := s.Broadcaster(id)
-	hub.Publish(event)
+	s.mu.RLock()
+	r, ok := s.rooms[id]
+	s.mu.RUnlock()
+	if !ok || r.hub == nil {
+		return
+	}
+	r.hub.Publish(event)
+}
+
+// Delete removes the room. It cancels and wakes the room's loop, waits up to
+// timeout for it to exit, then closes the broadcaster and forgets the room.
+// It reports false if the loop was still running when timeout elapsed.
+func (s *RoomStore[T]) Delete(id string, timeout time.Duration) bool {
+	s.mu.Lock()
+	cancel, hasLoop := s.loops[id]
+	wake := s.wakes[id]
+	done := s.dones[id]
+	s.mu.Unlock()
+
+	exited := true
+	if hasLoop {
+		cancel()
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			exited = false
+		}
+	}
+
+	s.mu.Lock()
+	r, ok := s.rooms[id]
+	delete(s.rooms, id)
+	delete(s.loops, id)
+	delete(s.wakes, id)
+	delete(s.dones, id)
+	s.mu.Unlock()
+	if ok {
+		r.cancel()
+		if r.hub != nil {
+			r.hub.Close()
+		}
+	}
+	return exited
+}
+
+// closeTimeout bounds how long Close waits for each room's loop to exit.
+const closeTimeout = 2 * time.Second
+
+// Close deletes every room concurrently, stopping its loop and closing its
+// broadcaster. It reports an error if any loop failed to exit in time.
+func (s *RoomStore[T]) Close() error {
+	s.mu.RLock()
+	ids := make(map[string]struct{}, len(s.rooms)+len(s.loops))
+	for id := range s.rooms {
+		ids[id] = struct{}{}
+	}
+	for id := range s.loops {
+		ids[id] = struct{}{}
+	}
+	s.mu.RUnlock()
+
+	var stuck atomic.Int32
+	var wg sync.WaitGroup
+	for id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !s.Delete(id, closeTimeout) {
+				stuck.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := stuck.Load(); n > 0 {
+		return fmt.Errorf("realtime: %d room loops did not exit within %s", n, closeTimeout)
+	}
+	return nil
+}
+
+// Drain drains every room's broadcaster concurrently (see Broadcaster.Drain),
+// so subscribers receive in-flight events before Close tears the rooms down.
+func (s *RoomStore[T]) Drain(ctx context.Context) error {
+	s.mu.RLock()
+	hubs := make([]*HistoryBroadcaster, 0, len(s.rooms))
+	for _, r := range s.rooms {
+		if r.hub != nil {
+			hubs = append(hubs, r.hub)
+		}
+	}
+	s.mu.RUnlock()
+
+	errs := make([]error, len(hubs))
+	var wg sync.WaitGroup
+	for i, hub := range hubs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = hub.Drain(ctx)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("realtime: drain: %w", err)
+		}
+	}
+	return nil
 }
 
 // Broadcaster returns the broadcaster for the room, creating it if the room exists but had none.
-func (s *RoomStore[T]) Broadcaster(id string) *Broadcaster {
+func (s *RoomStore[T]) Broadcaster(id string) *HistoryBroadcaster {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	r, ok := s.rooms[id]
 	if !ok {
-		hub := NewBroadcaster()
-		s.rooms[id] = &Room[T]{ID: id, hub: hub}
-		return hub
+		var zero T
+		r = newRoom(id, zero)
+		s.rooms[id] = r
+		return r.hub
 	}
 	if r.hub == nil {
-		r.hub = NewBroadcaster()
+		r.hub = newRoomHub()
 	}
 	return r.hub
 }
 
+func newRoomHub() *HistoryBroadcaster {
+	return NewHistoryBroadcaster(NewBroadcaster(), DefaultHistorySize)
+}
+
 // TickFunc is called by RunLoop to determine the next wake time and events to publish.
-// stop true means exit the loop.
+// A tick returns nil events when state hasn't changed. stop true means exit the loop.
 type TickFunc[T any] func(state T, now time.Time) (next time.Time, events []string, stop bool)
 
+// LoopOptions controls how RunLoop handles a panicking TickFunc.
+type LoopOptions struct {
+	// MaxRestarts is how many times a panicking loop is restarted. Zero
+	// disables recovery so the panic propagates; negative restarts forever.
+	MaxRestarts int
+	// RestartDelay is the pause before each restart.
+	RestartDelay time.Duration
+}
+
+// DefaultLoopOptions restarts a panicking loop up to three times, a second apart.
+func DefaultLoopOptions() LoopOptions {
+	return LoopOptions{MaxRestarts: 3, RestartDelay: time.Second}
+}
+
 // RunLoop starts a timing loop for the room. If a loop already exists for id, it is not started again.
-func (s *RoomStore[T]) RunLoop(id string, getState func() T, tick TickFunc[T]) {
+func (s *RoomStore[T]) RunLoop(id string, getState func() T, tick TickFunc[T], opts LoopOptions) {
 	s.mu.Lock()
 	if _, ok := s.loops[id]; ok {
 		s.mu.Unlock()
@@ -82,8 +278,10 @@ func (s *RoomStore[T]) RunLoop(id string, getState func() T, tick TickFunc[T]) {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	wake := make(chan struct{}, 1)
+	done := make(chan struct{})
 	s.loops[id] = cancel
 	s.wakes[id] = wake
+	s.dones[id] = done
 	s.mu.Unlock()
 
 	go func() {
@@ -91,45 +289,76 @@ func (s *RoomStore[T]) RunLoop(id string, getState func() T, tick TickFunc[T]) {
 			s.mu.Lock()
 			delete(s.loops, id)
 			delete(s.wakes, id)
+			delete(s.dones, id)
 			s.mu.Unlock()
+			close(done)
 		}()
 
-		for {
-			state := getState()
-			now := time.Now().UTC()
-			next, events, stop := tick(state, now)
-			if stop {
+		for restarts := 0; ; restarts++ {
+			if !s.runTicks(ctx, id, getState, tick, wake, opts.MaxRestarts != 0) {
 				return
 			}
-			// Publish events immediately so UI updates as soon as state advances
-			// (e.g. after cooldown when moving to next round), not when the next timer fires.
-			for _, e := range events {
-				s.Publish(id, e)
-			}
-			wait := time.Until(next)
-			if wait < 0 {
-				wait = 0
+			if opts.MaxRestarts > 0 && restarts >= opts.MaxRestarts {
+				slog.Error("loop giving up", slog.String("roomID", id), slog.Int("restarts", restarts))
+				return
 			}
-			timer := time.NewTimer(wait)
 			select {
 			case <-ctx.Done():
-				timer.Stop()
 				return
-			case <-timer.C:
-				// Timer fired; loop will re-run tick and publish any new events
-			case <-wake:
-				if !timer.Stop() {
-					select {
-					case <-timer.C:
-					default:
-					}
-				}
-				continue
+			case <-time.After(opts.RestartDelay):
 			}
 		}
 	}()
 }
 
+// runTicks drives tick until it stops or ctx is cancelled. With recoverPanics
+// set, a panic in tick is logged and reported as panicked instead of crashing.
+func (s *RoomStore[T]) runTicks(ctx context.Context, id string, getState func() T, tick TickFunc[T], wake chan struct{}, recoverPanics bool) (panicked bool) {
+	defer func() {
+		if !recoverPanics {
+			return
+		}
+		if r := recover(); r != nil {
+			slog.Error("loop panicked", slog.String("roomID", id), slog.Any("panic", r))
+			panicked = true
+		}
+	}()
+
+	for {
+		state := getState()
+		now := time.Now().UTC()
+		next, events, stop := tick(state, now)
+		if stop {
+			return false
+		}
+		// Publish events immediately so UI updates as soon as state advances
+		// (e.g. after cooldown when moving to next round), not when the next timer fires.
+		for _, e := range events {
+			s.Publish(id, e)
+		}
+		wait := time.Until(next)
+		if wait < 0 {
+			wait = 0
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+			// Timer fired; loop will re-run tick and publish any new events
+		case <-wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			continue
+		}
+	}
+}
+
 // Wake unblocks the room's loop so it recomputes immediately.
 func (s *RoomStore[T]) Wake(id string) {
 	s.mu.RLock()
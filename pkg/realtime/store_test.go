@@ -1,6 +1,10 @@
 package realtime
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+)
 
 func TestNewRoomStore(t *testing.T) {
 	s := NewRoomStore[string]()
@@ -47,3 +51,98 @@ func TestRoomStore_Wake_NoPanicWhenNoLoop(t *testing.T) {
 	s := NewRoomStore[string]()
 	s.Wake("nonexistent")
 }
+
+func TestRoomStore_Delete(t *testing.T) {
+	s := NewRoomStore[string]()
+	s.Create("r1", "x")
+	hub := s.Broadcaster("r1")
+	ch := hub.Subscribe()
+	s.RunLoop("r1", func() string { return "x" }, func(string, time.Time) (time.Time, []string, bool) {
+		return time.Now().Add(time.Hour), nil, false
+	}, LoopOptions{})
+
+	if !s.Delete("r1", 2*time.Second) {
+		t.Fatal("Delete reported the loop did not exit")
+	}
+	if _, open := <-ch; open {
+		t.Error("subscriber channel should be closed after Delete")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.rooms) != 0 || len(s.loops) != 0 || len(s.wakes) != 0 || len(s.dones) != 0 {
+		t.Errorf("maps not empty after Delete: rooms=%d loops=%d wakes=%d dones=%d",
+			len(s.rooms), len(s.loops), len(s.wakes), len(s.dones))
+	}
+}
+
+func TestRoomStore_Context_CancelledOnDelete(t *testing.T) {
+	s := NewRoomStore[string]()
+	if s.Context("missing").Err() == nil {
+		t.Error("context of an unknown room should already be cancelled")
+	}
+	s.Create("r1", "x")
+	ctx := s.Context("r1")
+	if ctx.Err() != nil {
+		t.Fatal("context cancelled before Delete")
+	}
+	s.Delete("r1", time.Second)
+	if ctx.Err() == nil {
+		t.Error("context should be cancelled after Delete")
+	}
+}
+
+func TestRoomStore_RunLoop_RestartsAfterPanic(t *testing.T) {
+	s := NewRoomStore[string]()
+	s.Create("r1", "x")
+	calls := make(chan int, 10)
+	n := 0
+	s.RunLoop("r1", func() string { return "x" }, func(string, time.Time) (time.Time, []string, bool) {
+		n++
+		calls <- n
+		panic("boom")
+	}, LoopOptions{MaxRestarts: 2, RestartDelay: time.Millisecond})
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case got := <-calls:
+			if got != want {
+				t.Fatalf("call %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("tick %d never ran", want)
+		}
+	}
+	if !s.Delete("r1", time.Second) {
+		t.Fatal("loop still running after exhausting restarts")
+	}
+	if n != 3 {
+		t.Errorf("tick ran %d times, want 3 (1 + MaxRestarts)", n)
+	}
+}
+
+func TestRoomStore_DrainWaitsForEveryRoom(t *testing.T) {
+	s := NewRoomStore[string]()
+	s.Create("a", "")
+	s.Create("b", "")
+	subA := s.Broadcaster("a").Subscribe()
+	subB := s.Broadcaster("b").Subscribe()
+	s.Publish("a", "round")
+	s.Publish("b", "scores")
+
+	done := make(chan error, 1)
+	go func() { done <- s.Drain(context.Background()) }()
+	if got := <-subA; got != "round" {
+		t.Errorf("room a got %q, want round", got)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Drain returned %v before room b was read", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := <-subB; got != "scores" {
+		t.Errorf("room b got %q, want scores", got)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Drain = %v, want nil", err)
+	}
+}
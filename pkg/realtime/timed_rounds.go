@@ -1,6 +1,9 @@
 package realtime
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // TimedRounds holds the timing state for a sequence of rounds: duration per round,
 // cooldown between rounds, and when the current round started/ended.
@@ -13,6 +16,88 @@ type TimedRounds struct {
 	CurrentRound int
 	RoundStarted time.Time
 	RoundEndedAt time.Time
+	// Extra is time added to the current round by Extend. It only moves the
+	// deadline; RoundStarted and Duration still describe the scored round.
+	Extra time.Duration
+}
+
+// MaxExtra caps how much Extend can add to a single round in total.
+const MaxExtra = time.Minute
+
+// TimedRoundsJSON is the JSON form of TimedRounds: durations in whole
+// milliseconds and times as RFC 3339 strings with nanoseconds, omitted when
+// unset.
+type TimedRoundsJSON struct {
+	DurationMs   int64  `json:"duration_ms"`
+	CooldownMs   int64  `json:"cooldown_ms"`
+	Rounds       int    `json:"rounds"`
+	CurrentRound int    `json:"current_round"`
+	RoundStarted string `json:"round_started,omitempty"`
+	RoundEndedAt string `json:"round_ended_at,omitempty"`
+	ExtraMs      int64  `json:"extra_ms,omitempty"`
+}
+
+// MarshalJSON encodes t as TimedRoundsJSON.
+func (t TimedRounds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(TimedRoundsJSON{
+		DurationMs:   t.Duration.Milliseconds(),
+		CooldownMs:   t.Cooldown.Milliseconds(),
+		Rounds:       t.Rounds,
+		CurrentRound: t.CurrentRound,
+		RoundStarted: formatJSONTime(t.RoundStarted),
+		RoundEndedAt: formatJSONTime(t.RoundEndedAt),
+		ExtraMs:      t.Extra.Milliseconds(),
+	})
+}
+
+// UnmarshalJSON decodes TimedRoundsJSON. It also accepts the field-per-field
+// form (nanosecond durations) that games were saved with before TimedRounds
+// had its own encoding.
+func (t *TimedRounds) UnmarshalJSON(data []byte) error {
+	var legacy struct{ Duration json.RawMessage }
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	if legacy.Duration != nil {
+		type plain TimedRounds // drops the methods, so this decodes field by field
+		return json.Unmarshal(data, (*plain)(t))
+	}
+	var v TimedRoundsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	started, err := parseJSONTime(v.RoundStarted)
+	if err != nil {
+		return err
+	}
+	ended, err := parseJSONTime(v.RoundEndedAt)
+	if err != nil {
+		return err
+	}
+	*t = TimedRounds{
+		Duration:     time.Duration(v.DurationMs) * time.Millisecond,
+		Cooldown:     time.Duration(v.CooldownMs) * time.Millisecond,
+		Rounds:       v.Rounds,
+		CurrentRound: v.CurrentRound,
+		RoundStarted: started,
+		RoundEndedAt: ended,
+		Extra:        time.Duration(v.ExtraMs) * time.Millisecond,
+	}
+	return nil
+}
+
+func formatJSONTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseJSONTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
 }
 
 // DefaultCooldown is the usual pause between rounds (e.g. 5s).
@@ -25,7 +110,7 @@ func (t *TimedRounds) NextWake(now time.Time) (time.Time, bool) {
 		return time.Time{}, false
 	}
 	if t.RoundEndedAt.IsZero() {
-		return t.RoundStarted.Add(t.Duration), true
+		return t.Deadline(), true
 	}
 	next := t.RoundEndedAt.Add(t.Cooldown)
 	if now.After(next) {
@@ -43,7 +128,7 @@ func (t *TimedRounds) Advance(now time.Time) (advanced bool, finished bool) {
 	if t.RoundStarted.IsZero() {
 		return false, false
 	}
-	roundEnd := t.RoundStarted.Add(t.Duration)
+	roundEnd := t.Deadline()
 	if t.RoundEndedAt.IsZero() && now.After(roundEnd) {
 		t.RoundEndedAt = roundEnd
 		return true, false
@@ -60,12 +145,57 @@ func (t *TimedRounds) Advance(now time.Time) (advanced bool, finished bool) {
 	t.CurrentRound++
 	t.RoundStarted = now
 	t.RoundEndedAt = time.Time{}
+	t.Extra = 0
 	return true, false
 }
 
+// Deadline is when the current round times out: its Duration plus any Extra
+// after RoundStarted.
+func (t *TimedRounds) Deadline() time.Time {
+	return t.RoundStarted.Add(t.Duration + t.Extra)
+}
+
+// RemainingRounds returns how many rounds are still to come after the current one.
+func (t *TimedRounds) RemainingRounds() int {
+	if t.CurrentRound >= t.Rounds {
+		return 0
+	}
+	return t.Rounds - t.CurrentRound
+}
+
+// IsLastRound reports whether no rounds follow the current one.
+func (t *TimedRounds) IsLastRound() bool {
+	return t.RemainingRounds() == 0
+}
+
+// SkipRound ends the current round early at now, starting the cooldown.
+// It does nothing if the round has not started or has already ended.
+func (t *TimedRounds) SkipRound(now time.Time) {
+	if t.RoundStarted.IsZero() || !t.RoundEndedAt.IsZero() {
+		return
+	}
+	t.RoundEndedAt = now
+}
+
+// Extend gives the current round up to d more time, never more than MaxExtra
+// in total, and returns how much it added. It adds nothing if the round has
+// not started, has already ended, or d is not positive.
+func (t *TimedRounds) Extend(d time.Duration) time.Duration {
+	if d <= 0 || t.RoundStarted.IsZero() || !t.RoundEndedAt.IsZero() {
+		return 0
+	}
+	d = min(d, MaxExtra-t.Extra)
+	if d <= 0 {
+		return 0
+	}
+	t.Extra += d
+	return d
+}
+
 // Start begins the first round at now. Call when the game leaves lobby.
 func (t *TimedRounds) Start(now time.Time) {
 	t.CurrentRound = 1
 	t.RoundStarted = now
 	t.RoundEndedAt = time.Time{}
+	t.Extra = 0
 }
@@ -1,6 +1,7 @@
 package realtime
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -112,3 +113,173 @@ func TestTimedRounds_Start(t *testing.T) {
 		t.Error("RoundEndedAt should be zero")
 	}
 }
+
+func TestTimedRounds_SkipRound(t *testing.T) {
+	now := time.Now().UTC()
+	tr := TimedRounds{
+		Rounds:       2,
+		Duration:     time.Minute,
+		Cooldown:     50 * time.Millisecond,
+		CurrentRound: 1,
+		RoundStarted: now,
+	}
+	skipAt := now.Add(10 * time.Second)
+	tr.SkipRound(skipAt)
+	if !tr.RoundEndedAt.Equal(skipAt) {
+		t.Fatalf("RoundEndedAt %v, want %v", tr.RoundEndedAt, skipAt)
+	}
+	tr.SkipRound(skipAt.Add(time.Second))
+	if !tr.RoundEndedAt.Equal(skipAt) {
+		t.Error("SkipRound on an ended round should not move RoundEndedAt")
+	}
+	advanced, finished := tr.Advance(skipAt.Add(100 * time.Millisecond))
+	if !advanced || finished {
+		t.Errorf("Advance after cooldown = (%v, %v), want (true, false)", advanced, finished)
+	}
+	if tr.CurrentRound != 2 {
+		t.Errorf("CurrentRound %d, want 2", tr.CurrentRound)
+	}
+}
+
+func TestTimedRounds_Extend(t *testing.T) {
+	now := time.Now().UTC()
+	tr := TimedRounds{Rounds: 2, Duration: time.Minute, CurrentRound: 1, RoundStarted: now}
+	if got := tr.Extend(10 * time.Second); got != 10*time.Second {
+		t.Fatalf("Extend added %v, want 10s", got)
+	}
+	if !tr.RoundStarted.Equal(now) {
+		t.Errorf("RoundStarted moved to %v; scoring must keep the real start", tr.RoundStarted)
+	}
+	if want := now.Add(70 * time.Second); !tr.Deadline().Equal(want) {
+		t.Errorf("Deadline %v, want %v", tr.Deadline(), want)
+	}
+	if next, _ := tr.NextWake(now); !next.Equal(now.Add(70 * time.Second)) {
+		t.Errorf("NextWake %v, want the extended deadline", next)
+	}
+	if advanced, _ := tr.Advance(now.Add(65 * time.Second)); advanced {
+		t.Error("round ended before the extended deadline")
+	}
+	if got := tr.Extend(-time.Second); got != 0 || tr.Extra != 10*time.Second {
+		t.Errorf("negative Extend added %v, Extra %v", got, tr.Extra)
+	}
+	if advanced, _ := tr.Advance(now.Add(71 * time.Second)); !advanced || !tr.RoundEndedAt.Equal(now.Add(70*time.Second)) {
+		t.Errorf("RoundEndedAt %v, want the extended deadline", tr.RoundEndedAt)
+	}
+	if got := tr.Extend(time.Second); got != 0 {
+		t.Errorf("Extend on an ended round added %v", got)
+	}
+	tr.Advance(now.Add(time.Hour))
+	if tr.CurrentRound != 2 || tr.Extra != 0 {
+		t.Errorf("next round: CurrentRound %d, Extra %v, want 2 and 0", tr.CurrentRound, tr.Extra)
+	}
+	var idle TimedRounds
+	if got := idle.Extend(time.Second); got != 0 || idle.Extra != 0 {
+		t.Error("Extend before Start should do nothing")
+	}
+}
+
+func TestTimedRounds_Extend_CapsTotal(t *testing.T) {
+	now := time.Now().UTC()
+	tr := TimedRounds{Rounds: 1, Duration: time.Minute, CurrentRound: 1, RoundStarted: now}
+	var total time.Duration
+	for i := 0; i < 10; i++ {
+		total += tr.Extend(25 * time.Second)
+	}
+	if total != MaxExtra || tr.Extra != MaxExtra {
+		t.Errorf("ten extends added %v (Extra %v), want capped at %v", total, tr.Extra, MaxExtra)
+	}
+	if got := tr.Extend(time.Second); got != 0 {
+		t.Errorf("Extend past MaxExtra added %v", got)
+	}
+}
+
+func TestTimedRounds_RemainingRounds(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       int
+		wantLeft      int
+		wantLastRound bool
+	}{
+		{"mid-game", 1, 2, false},
+		{"last round", 3, 0, true},
+		{"after finish", 4, 0, true},
+	}
+	for _, tt := range tests {
+		tr := TimedRounds{Rounds: 3, CurrentRound: tt.current}
+		if got := tr.RemainingRounds(); got != tt.wantLeft {
+			t.Errorf("%s: RemainingRounds %d, want %d", tt.name, got, tt.wantLeft)
+		}
+		if got := tr.IsLastRound(); got != tt.wantLastRound {
+			t.Errorf("%s: IsLastRound %v, want %v", tt.name, got, tt.wantLastRound)
+		}
+	}
+}
+
+func TestTimedRounds_JSONRoundTrip(t *testing.T) {
+	started := time.Date(2026, 3, 14, 15, 9, 26, 535897932, time.UTC)
+	tests := []struct {
+		name string
+		tr   TimedRounds
+	}{
+		{"fully populated", TimedRounds{
+			Duration:     90 * time.Second,
+			Cooldown:     DefaultCooldown,
+			Rounds:       5,
+			CurrentRound: 3,
+			RoundStarted: started,
+			RoundEndedAt: started.Add(42*time.Second + 7*time.Nanosecond),
+			Extra:        15 * time.Second,
+		}},
+		{"not started", TimedRounds{Duration: time.Minute, Cooldown: 1500 * time.Millisecond, Rounds: 2}},
+		{"zero", TimedRounds{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.tr)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var got TimedRounds
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal %s: %v", data, err)
+			}
+			if got.Duration != tt.tr.Duration || got.Cooldown != tt.tr.Cooldown ||
+				got.Rounds != tt.tr.Rounds || got.CurrentRound != tt.tr.CurrentRound ||
+				!got.RoundStarted.Equal(tt.tr.RoundStarted) || !got.RoundEndedAt.Equal(tt.tr.RoundEndedAt) ||
+				got.Extra != tt.tr.Extra {
+				t.Errorf("round trip via %s:\n got %+v\nwant %+v", data, got, tt.tr)
+			}
+		})
+	}
+}
+
+func TestTimedRounds_MarshalJSON_Format(t *testing.T) {
+	tr := TimedRounds{
+		Duration:     90 * time.Second,
+		Cooldown:     DefaultCooldown,
+		Rounds:       3,
+		CurrentRound: 1,
+		RoundStarted: time.Date(2026, 1, 2, 3, 4, 5, 600, time.UTC),
+	}
+	data, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"duration_ms":90000,"cooldown_ms":5000,"rounds":3,"current_round":1,"round_started":"2026-01-02T03:04:05.0000006Z"}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
+
+func TestTimedRounds_UnmarshalJSON_Legacy(t *testing.T) {
+	// The shape games were saved in before TimedRounds had MarshalJSON.
+	data := `{"Duration":60000000000,"Cooldown":5000000000,"Rounds":2,"CurrentRound":1,"RoundStarted":"2026-01-02T03:04:05Z","RoundEndedAt":"0001-01-01T00:00:00Z"}`
+	var got TimedRounds
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := TimedRounds{Duration: time.Minute, Cooldown: DefaultCooldown, Rounds: 2, CurrentRound: 1, RoundStarted: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+package realtime
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// websocketGUID is the fixed key suffix from RFC 6455 section 1.3.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxClientFrame caps client payloads; the server only expects control frames.
+const maxClientFrame = 1 << 16
+
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// WebSocketConn is a minimal server-side WebSocket used as a fallback for
+// clients whose proxies buffer SSE. It only sends text frames; anything the
+// client sends other than ping/close is discarded.
+type WebSocketConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+
+	mu        sync.Mutex // serialises writes
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// IsWebSocketUpgrade reports whether r asks to switch to the WebSocket protocol.
+func IsWebSocketUpgrade(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+	for _, token := range strings.Split(r.Header.Get("Connection"), ",") {
+		if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+			return true
+		}
+	}
+	return false
+}
+
+// UpgradeWebSocket completes the opening handshake and takes over the connection.
+// On failure an HTTP error has already been written to w.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocketConn, error) {
+	key := strings.TrimSpace(r.Header.Get("Sec-WebSocket-Key"))
+	if r.Method != http.MethodGet || key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "bad websocket handshake", http.StatusBadRequest)
+		return nil, errors.New("bad websocket handshake")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket unsupported", http.StatusInternalServerError)
+		return nil, errors.New("response writer cannot hijack")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c := &WebSocketConn{conn: conn, rw: rw, done: make(chan struct{})}
+	go c.readLoop()
+	return c, nil
+}
+
+// Done is closed once the client disconnects or the connection is closed.
+func (c *WebSocketConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// WriteEvent sends one named event as a JSON text frame: {"event": ..., "data": ...}.
+func (c *WebSocketConn) WriteEvent(event, data string) error {
+	payload, err := json.Marshal(struct {
+		Event string `json:"event"`
+		Data  string `json:"data"`
+	}{event, data})
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(opText, payload)
+}
+
+// Ping sends a ping control frame; it doubles as the stream keep-alive.
+func (c *WebSocketConn) Ping() error {
+	return c.writeFrame(opPing, nil)
+}
+
+// Close sends a close frame and releases the connection.
+func (c *WebSocketConn) Close() error {
+	_ = c.writeFrame(opClose, nil)
+	c.shutdown()
+	return c.conn.Close()
+}
+
+func (c *WebSocketConn) shutdown() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+func (c *WebSocketConn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	header := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// readLoop answers pings and watches for the client going away.
+func (c *WebSocketConn) readLoop() {
+	defer c.shutdown()
+	for {
+		op, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch op {
+		case opClose:
+			return
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *WebSocketConn) readFrame() (op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	op = head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > maxClientFrame {
+		return 0, nil, errors.New("websocket frame too large")
+	}
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.rw, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return op, payload, nil
+}
@@ -0,0 +1,79 @@
+package realtime
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if IsWebSocketUpgrade(r) {
+		t.Error("plain request reported as upgrade")
+	}
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	if !IsWebSocketUpgrade(r) {
+		t.Error("upgrade request not detected")
+	}
+}
+
+func TestUpgradeWebSocket_WriteEvent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		_ = c.WriteEvent("round", "<p>hi</p>")
+		<-c.Done()
+	}))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, _ = io.WriteString(conn, "GET / HTTP/1.1\r\nHost: x\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status %d, want 101", resp.StatusCode)
+	}
+	// Accept value from the RFC 6455 example handshake.
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept %q", got)
+	}
+
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(br, head); err != nil {
+		t.Fatal(err)
+	}
+	if head[0] != 0x81 {
+		t.Fatalf("frame header %#x, want final text frame", head[0])
+	}
+	payload := make([]byte, head[1]&0x7F)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		t.Fatal(err)
+	}
+	var msg struct{ Event, Data string }
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Event != "round" || msg.Data != "<p>hi</p>" {
+		t.Errorf("got %+v", msg)
+	}
+	// Masked close frame from the client ends the server loop.
+	_, _ = conn.Write([]byte{0x88, 0x80, 1, 2, 3, 4})
+}
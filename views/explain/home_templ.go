@@ -8,7 +8,13 @@ package explainviews
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
-func HomePage() templ.Component {
+import (
+	"strconv"
+
+	"dagame/internal/explain/viewmodel"
+)
+
+func HomePage(languages []viewmodel.LanguageOption, recent []viewmodel.GameSummary) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -37,7 +43,148 @@ func HomePage() templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</head><body><section class=\"section\"><div class=\"container\"><div class=\"columns is-centered\"><div class=\"column is-half\"><h1 class=\"title is-2\">Explain 🤔</h1><p class=\"subtitle\">One player explains a word using only emojis — others guess!</p><div class=\"card\"><div class=\"card-content\"><h2 class=\"title is-5\">Create a new game</h2><form method=\"POST\" action=\"/games\"><div class=\"field\"><label class=\"label\" for=\"rounds\">Rounds</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"rounds\" name=\"rounds\" value=\"3\" min=\"1\" max=\"10\" required></div></div><div class=\"field\"><label class=\"label\" for=\"duration\">Seconds per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"duration\" name=\"duration\" value=\"90\" min=\"30\" max=\"300\" required></div><p class=\"help\">Each round lasts this many seconds.</p></div><div class=\"field\"><label class=\"label\" for=\"emojis\">Emojis per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"emojis\" name=\"emojis\" value=\"8\" min=\"4\" max=\"20\" required></div><p class=\"help\">How many emojis the explainer gets to work with.</p></div><div class=\"field\"><div class=\"control\"><button type=\"submit\" class=\"button is-primary\">Create game</button></div></div></form></div></div></div></div></div></section></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</head><body><section class=\"section\"><div class=\"container\"><div class=\"columns is-centered\"><div class=\"column is-half\"><h1 class=\"title is-2\">Explain 🤔</h1><p class=\"subtitle\">One player explains a word using only emojis — others guess!</p><div class=\"card\"><div class=\"card-content\"><h2 class=\"title is-5\">Create a new game</h2><form method=\"POST\" action=\"/games\"><div class=\"field\"><label class=\"label\" for=\"lang\">Language</label><div class=\"control\"><div class=\"select is-fullwidth\"><select id=\"lang\" name=\"lang\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		for _, l := range languages {
+			if l.Code == "en" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<option value=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var2 string
+				templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(l.Code)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 39, Col: 38}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" selected>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var3 string
+				templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(l.Label)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 39, Col: 59}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</option>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<option value=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var4 string
+				templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(l.Code)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 41, Col: 38}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var5 string
+				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(l.Label)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 41, Col: 50}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</option>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</select></div></div></div><div class=\"field\"><label class=\"label\" for=\"rounds\">Rounds</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"rounds\" name=\"rounds\" value=\"3\" min=\"1\" max=\"10\" required></div></div><div class=\"field\"><label class=\"label\" for=\"duration\">Seconds per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"duration\" name=\"duration\" value=\"90\" min=\"30\" max=\"300\" required></div><p class=\"help\">Each round lasts this many seconds.</p></div><div class=\"field\"><label class=\"label\" for=\"emojis\">Emojis per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"emojis\" name=\"emojis\" value=\"8\" min=\"4\" max=\"20\" required></div><p class=\"help\">How many emojis the explainer gets to work with.</p></div><div class=\"field\"><label class=\"label\" for=\"allow_repeat_emoji\">Emoji reuse</label><div class=\"control\"><div class=\"select is-fullwidth\"><select id=\"allow_repeat_emoji\" name=\"allow_repeat_emoji\"><option value=\"true\" selected>Each emoji can be placed several times</option> <option value=\"false\">Each emoji can be placed once</option></select></div></div></div><div class=\"field\"><label class=\"label\" for=\"password\">Room password</label><div class=\"control\"><input class=\"input\" type=\"password\" id=\"password\" name=\"password\" maxlength=\"64\" autocomplete=\"new-password\" placeholder=\"Optional\"></div><p class=\"help\">Players must enter it to join. It is not part of the invite link, so share it separately.</p></div><div class=\"field\"><div class=\"control\"><button type=\"submit\" class=\"button is-primary\">Create game</button></div></div></form></div></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if len(recent) > 0 {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "<div class=\"card mt-4\"><div class=\"card-content\"><h2 class=\"title is-5\">Join ongoing game</h2><ul role=\"list\" aria-label=\"Ongoing games\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			for _, game := range recent {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "<li class=\"mb-2\"><a href=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var6 templ.SafeURL
+				templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/game/" + game.ID))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 101, Col: 56}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var7 string
+				templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(game.ID)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 101, Col: 68}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "</a> <span class=\"tag is-light ml-2\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var8 string
+				templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(game.Lang)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 102, Col: 56}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "</span> <span class=\"has-text-grey ml-2\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var9 string
+				templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(gameSummaryLabel(game))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/explain/home.templ`, Line: 103, Col: 70}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "</span></li>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</ul></div></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</div></div></div></section></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -45,4 +192,17 @@ func HomePage() templ.Component {
 	})
 }
 
+// gameSummaryLabel reads as "3 players · in lobby".
+func gameSummaryLabel(game viewmodel.GameSummary) string {
+	players := strconv.Itoa(game.PlayerCount) + " players"
+	if game.PlayerCount == 1 {
+		players = "1 player"
+	}
+	status := "in lobby"
+	if game.Status == "in_progress" {
+		status = "in progress"
+	}
+	return players + " · " + status
+}
+
 var _ = templruntime.GeneratedTemplate
@@ -30,7 +30,7 @@ func explainStyles() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<style>\n\t\t@import url(\"https://fonts.googleapis.com/css2?family=Fredoka:wght@400;600&display=swap\");\n\n\t\thtml, body {\n\t\t\tbackground: radial-gradient(circle at top left, #fff4d6 0%, #f8f3ff 35%, #e8f7ff 100%);\n\t\t\tfont-family: \"Fredoka\", \"Trebuchet MS\", \"Arial Rounded MT Bold\", Arial, sans-serif;\n\t\t\tmin-height: 100%;\n\t\t\tbackground-attachment: fixed;\n\t\t}\n\n\t\th1.title, h2.title, .subtitle { letter-spacing: 0.4px; }\n\n\t\t.card {\n\t\t\tbox-shadow: 0 16px 32px rgba(76, 90, 204, 0.12);\n\t\t\tborder: 2px solid #f1ecff;\n\t\t\tborder-radius: 16px;\n\t\t}\n\n\t\t.button.is-primary, .button.is-info, .button.is-link {\n\t\t\tbox-shadow: 0 8px 18px rgba(108, 99, 255, 0.3);\n\t\t\tborder-radius: 999px;\n\t\t}\n\n\t\t/* Invite URL bar */\n\t\t.invite-url input {\n\t\t\tfont-family: \"SFMono-Regular\", ui-monospace, monospace;\n\t\t\tfont-size: 0.85rem;\n\t\t}\n\n\t\t/* Canvas drop zone */\n\t\t.canvas-area {\n\t\t\tmin-height: 300px;\n\t\t\tborder: 2px dashed #d8c8ff;\n\t\t\tborder-radius: 12px;\n\t\t\tbackground: #faf8ff;\n\t\t\tposition: relative;\n\t\t\toverflow: hidden;\n\t\t}\n\t\t.canvas-area:empty::after {\n\t\t\tcontent: \"Canvas is empty\";\n\t\t\tposition: absolute;\n\t\t\ttop: 50%;\n\t\t\tleft: 50%;\n\t\t\ttransform: translate(-50%, -50%);\n\t\t\tcolor: #c0b4e8;\n\t\t\tfont-size: 0.9rem;\n\t\t}\n\n\t\t/* Emoji palette */\n\t\t.emoji-palette { display: flex; flex-wrap: wrap; gap: 0.4rem; }\n\t\t.emoji-btn { border-radius: 10px !important; }\n\n\t\t/* Word letter boxes */\n\t\t.word-letters {\n\t\t\tdisplay: flex;\n\t\t\tflex-wrap: wrap;\n\t\t\tgap: 0.5rem;\n\t\t\tlist-style: none;\n\t\t\tpadding: 0;\n\t\t\tmargin: 0.5rem 0 1rem;\n\t\t}\n\t\t.word-letter {\n\t\t\tmin-width: 2.6rem;\n\t\t\theight: 3.2rem;\n\t\t\tdisplay: flex;\n\t\t\talign-items: center;\n\t\t\tjustify-content: center;\n\t\t\tbackground: #fff;\n\t\t\tborder: 2px solid #a070e8;\n\t\t\tborder-radius: 10px;\n\t\t\tfont-size: 1.5rem;\n\t\t\tfont-weight: 600;\n\t\t\tpadding: 0 0.4rem;\n\t\t\tcolor: #3a2060;\n\t\t}\n\t\t.word-letter.is-blank {\n\t\t\tbackground: #fff;\n\t\t\tborder: 2px solid #d0c0f0;\n\t\t\tcolor: #c0a8f0;\n\t\t}\n\t\t.word-letter.is-space {\n\t\t\tborder: none;\n\t\t\tbackground: transparent;\n\t\t\tmin-width: 1rem;\n\t\t}\n\n\t\t/* Settings sidebar */\n\t\t.settings-item { display: flex; justify-content: space-between; padding: 0.25rem 0; border-bottom: 1px solid #f0ecff; }\n\t\t.settings-item:last-child { border-bottom: none; }\n\n\t\t/* Notification for game-start state */\n\t\t.notification.is-light {\n\t\t\tbackground: rgba(255,255,255,0.9);\n\t\t\tborder: 2px dashed #d8c8ff;\n\t\t}\n\t</style>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<style>\n\t\t@import url(\"https://fonts.googleapis.com/css2?family=Fredoka:wght@400;600&display=swap\");\n\n\t\thtml, body {\n\t\t\tbackground: radial-gradient(circle at top left, #fff4d6 0%, #f8f3ff 35%, #e8f7ff 100%);\n\t\t\tfont-family: \"Fredoka\", \"Trebuchet MS\", \"Arial Rounded MT Bold\", Arial, sans-serif;\n\t\t\tmin-height: 100%;\n\t\t\tbackground-attachment: fixed;\n\t\t}\n\n\t\th1.title, h2.title, .subtitle { letter-spacing: 0.4px; }\n\n\t\t.card {\n\t\t\tbox-shadow: 0 16px 32px rgba(76, 90, 204, 0.12);\n\t\t\tborder: 2px solid #f1ecff;\n\t\t\tborder-radius: 16px;\n\t\t}\n\n\t\t.button.is-primary, .button.is-info, .button.is-link {\n\t\t\tbox-shadow: 0 8px 18px rgba(108, 99, 255, 0.3);\n\t\t\tborder-radius: 999px;\n\t\t}\n\n\t\t/* Invite URL bar */\n\t\t.invite-url input {\n\t\t\tfont-family: \"SFMono-Regular\", ui-monospace, monospace;\n\t\t\tfont-size: 0.85rem;\n\t\t}\n\n\t\t/* Canvas drop zone */\n\t\t.canvas-area {\n\t\t\tmin-height: 300px;\n\t\t\tborder: 2px dashed #d8c8ff;\n\t\t\tborder-radius: 12px;\n\t\t\tbackground: #faf8ff;\n\t\t\tposition: relative;\n\t\t\toverflow: hidden;\n\t\t}\n\t\t.canvas-area:empty::after {\n\t\t\tcontent: \"Canvas is empty\";\n\t\t\tposition: absolute;\n\t\t\ttop: 50%;\n\t\t\tleft: 50%;\n\t\t\ttransform: translate(-50%, -50%);\n\t\t\tcolor: #c0b4e8;\n\t\t\tfont-size: 0.9rem;\n\t\t}\n\n\t\t/* Emoji palette */\n\t\t.emoji-palette { display: flex; flex-wrap: wrap; gap: 0.4rem; }\n\t\t.emoji-btn { border-radius: 10px !important; }\n\n\t\t/* Word letter boxes */\n\t\t.word-letters {\n\t\t\tdisplay: flex;\n\t\t\tflex-wrap: wrap;\n\t\t\tgap: 0.5rem;\n\t\t\tlist-style: none;\n\t\t\tpadding: 0;\n\t\t\tmargin: 0.5rem 0 1rem;\n\t\t}\n\t\t.word-letter {\n\t\t\tmin-width: 2.6rem;\n\t\t\theight: 3.2rem;\n\t\t\tdisplay: flex;\n\t\t\talign-items: center;\n\t\t\tjustify-content: center;\n\t\t\tbackground: #fff;\n\t\t\tborder: 2px solid #a070e8;\n\t\t\tborder-radius: 10px;\n\t\t\tfont-size: 1.5rem;\n\t\t\tfont-weight: 600;\n\t\t\tpadding: 0 0.4rem;\n\t\t\tcolor: #3a2060;\n\t\t}\n\t\t.word-letter.is-blank {\n\t\t\tbackground: #fff;\n\t\t\tborder: 2px solid #d0c0f0;\n\t\t\tcolor: #c0a8f0;\n\t\t}\n\t\t.word-letter.is-space {\n\t\t\tborder: none;\n\t\t\tbackground: transparent;\n\t\t\tmin-width: 1rem;\n\t\t}\n\n\t\t/* Guesser reactions */\n\t\t.reaction-buttons { display: flex; gap: 0.25rem; margin-top: 0.5rem; }\n\t\t.reaction-buttons .button { font-size: 1.2rem; }\n\t\t.reaction-layer { position: relative; height: 0; }\n\t\t.reaction-float {\n\t\t\tposition: absolute;\n\t\t\tright: 0.5rem;\n\t\t\tbottom: 0;\n\t\t\tfont-size: 2.4rem;\n\t\t\tpointer-events: none;\n\t\t\ttransition: transform 2s ease-out, opacity 2s ease-in;\n\t\t}\n\t\t.reaction-float.is-floating { transform: translateY(-140px); opacity: 0; }\n\n\t\t/* Players who guessed the word this round */\n\t\tli.is-solved { color: #257942; font-weight: 600; }\n\n\t\t/* Stream presence */\n\t\t.presence-dot { display: inline-block; width: 0.6rem; height: 0.6rem; border-radius: 50%; background: #f14668; }\n\t\t.presence-dot.is-online { background: #48c78e; }\n\n\t\t/* Settings sidebar */\n\t\t.settings-item { display: flex; justify-content: space-between; padding: 0.25rem 0; border-bottom: 1px solid #f0ecff; }\n\t\t.settings-item:last-child { border-bottom: none; }\n\n\t\t/* Notification for game-start state */\n\t\t.notification.is-light {\n\t\t\tbackground: rgba(255,255,255,0.9);\n\t\t\tborder: 2px dashed #d8c8ff;\n\t\t}\n\t</style>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -100,7 +100,7 @@ func HomePage(languages []viewmodel.LanguageOption) templ.Component {
 				}
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</select></div></div></div><div class=\"field\"><label class=\"label\" for=\"rounds\">Rounds</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"rounds\" name=\"rounds\" min=\"1\" max=\"10\" value=\"5\" required></div><p class=\"help\">Choose how many rounds this game should have.</p></div><div class=\"field\"><label class=\"label\" for=\"duration\">Seconds per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"duration\" name=\"duration\" min=\"10\" max=\"300\" value=\"60\" required></div><p class=\"help\">Each round will run for this many seconds.</p></div><div class=\"field\"><div class=\"control\"><button class=\"button is-primary\" type=\"submit\">Create game</button></div></div></form></div></div></div></div></div></section></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</select></div></div></div><div class=\"field\"><label class=\"label\" for=\"category\">Word category</label><div class=\"control\"><input class=\"input\" type=\"text\" id=\"category\" name=\"category\" maxlength=\"32\" placeholder=\"Any\"></div><p class=\"help\">Only deal words tagged with this category, e.g. animals. Leave blank for every word.</p></div><div class=\"field\"><label class=\"label\" for=\"rounds\">Rounds</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"rounds\" name=\"rounds\" min=\"1\" max=\"10\" value=\"5\" required></div><p class=\"help\">Choose how many rounds this game should have.</p></div><div class=\"field\"><label class=\"label\" for=\"duration\">Seconds per round</label><div class=\"control\"><input class=\"input\" type=\"number\" id=\"duration\" name=\"duration\" min=\"10\" max=\"300\" value=\"60\" required></div><p class=\"help\">Each round will run for this many seconds.</p></div><div class=\"field\"><label class=\"label\" for=\"time_bonus_multiplier\">Early guess bonus</label><div class=\"control\"><div class=\"select is-fullwidth\"><select id=\"time_bonus_multiplier\" name=\"time_bonus_multiplier\"><option value=\"1.0\" selected>None</option> <option value=\"1.5\">1.5×</option> <option value=\"2.0\">2×</option> <option value=\"3.0\">3×</option></select></div></div><p class=\"help\">Multiplies points for guesses in the first 20% of a round.</p></div><div class=\"field\"><label class=\"label\" for=\"fuzzy\">Typo tolerance</label><div class=\"control\"><div class=\"select is-fullwidth\"><select id=\"fuzzy\" name=\"fuzzy\"><option value=\"0\" selected>Exact spelling</option> <option value=\"1\">1 typo</option> <option value=\"2\">2 typos</option></select></div></div><p class=\"help\">Accept guesses this many letters off the word, for half points.</p></div><div class=\"field\"><div class=\"control\"><label class=\"checkbox\" for=\"multiple_winners\"><input type=\"checkbox\" id=\"multiple_winners\" name=\"multiple_winners\"> Everyone can solve each round</label></div><p class=\"help\">The round stays open until time runs out. The first solver earns the usual points, everyone after earns 1.</p></div><div class=\"field\"><label class=\"label\" for=\"password\">Room password</label><div class=\"control\"><input class=\"input\" type=\"password\" id=\"password\" name=\"password\" maxlength=\"64\" autocomplete=\"new-password\" placeholder=\"Optional\"></div><p class=\"help\">Players must enter it to join. It is not part of the invite link, so share it separately.</p></div><div class=\"field\"><div class=\"control\"><button class=\"button is-primary\" type=\"submit\">Create game</button></div></div></form></div></div></div></div></div></section></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}